@@ -0,0 +1,33 @@
+package akeneoxtest
+
+import (
+	"net/http"
+)
+
+func (s *Server) routeAssociationTypes(mux *http.ServeMux) {
+	associationTypes := &resource{
+		s:          s,
+		name:       "Association type",
+		properties: []string{"code", "labels", "is_quantified", "is_two_way"},
+		immutable:  []string{"is_quantified", "is_two_way"},
+		searchable: []string{"code"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.associationTypes, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":          "",
+				"labels":        map[string]any{},
+				"is_quantified": false,
+				"is_two_way":    false,
+			}
+		},
+		validate: func(r *http.Request, item, prior map[string]any) []violation {
+			if item["is_quantified"] == true && item["is_two_way"] == true {
+				return []violation{{Property: "is_two_way", Message: "A quantified association type can not be two-way."}}
+			}
+			return nil
+		},
+	}
+	associationTypes.route(mux, restPrefix+"/association-types", true, false)
+}
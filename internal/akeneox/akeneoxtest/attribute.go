@@ -0,0 +1,365 @@
+package akeneoxtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+var attributeTypes = []string{
+	"pim_catalog_identifier",
+	"pim_catalog_text",
+	"pim_catalog_textarea",
+	"pim_catalog_simpleselect",
+	"pim_catalog_multiselect",
+	"pim_catalog_boolean",
+	"pim_catalog_date",
+	"pim_catalog_number",
+	"pim_catalog_metric",
+	"pim_catalog_price_collection",
+	"pim_catalog_image",
+	"pim_catalog_file",
+	"pim_catalog_asset_collection",
+	"akeneo_reference_entity",
+	"akeneo_reference_entity_collection",
+	"pim_reference_data_simpleselect",
+	"pim_reference_data_multiselect",
+	"pim_catalog_table",
+}
+
+// attributeTypeProperties are the properties which can only be set for some
+// attribute types.
+var attributeTypeProperties = map[string][]string{
+	"max_characters":      {"pim_catalog_identifier", "pim_catalog_text", "pim_catalog_textarea"},
+	"validation_rule":     {"pim_catalog_identifier", "pim_catalog_text"},
+	"validation_regexp":   {"pim_catalog_identifier", "pim_catalog_text"},
+	"wysiwyg_enabled":     {"pim_catalog_textarea"},
+	"number_min":          {"pim_catalog_number", "pim_catalog_metric", "pim_catalog_price_collection"},
+	"number_max":          {"pim_catalog_number", "pim_catalog_metric", "pim_catalog_price_collection"},
+	"decimals_allowed":    {"pim_catalog_number", "pim_catalog_metric", "pim_catalog_price_collection"},
+	"negative_allowed":    {"pim_catalog_number", "pim_catalog_metric"},
+	"metric_family":       {"pim_catalog_metric"},
+	"default_metric_unit": {"pim_catalog_metric"},
+	"date_min":            {"pim_catalog_date"},
+	"date_max":            {"pim_catalog_date"},
+	"allowed_extensions":  {"pim_catalog_image", "pim_catalog_file"},
+	"max_file_size":       {"pim_catalog_image", "pim_catalog_file"},
+	"default_value":       {"pim_catalog_boolean"},
+	"table_configuration": {"pim_catalog_table"},
+	"reference_data_name": {
+		"pim_catalog_asset_collection",
+		"akeneo_reference_entity",
+		"akeneo_reference_entity_collection",
+		"pim_reference_data_simpleselect",
+		"pim_reference_data_multiselect",
+	},
+}
+
+// attributeTypeRequired are the properties required by some attribute types.
+var attributeTypeRequired = map[string][]string{
+	"pim_catalog_metric":                 {"metric_family", "default_metric_unit"},
+	"pim_catalog_asset_collection":       {"reference_data_name"},
+	"akeneo_reference_entity":            {"reference_data_name"},
+	"akeneo_reference_entity_collection": {"reference_data_name"},
+	"pim_reference_data_simpleselect":    {"reference_data_name"},
+	"pim_reference_data_multiselect":     {"reference_data_name"},
+	"pim_catalog_table":                  {"table_configuration"},
+}
+
+// uniqueAttributeTypes are the attribute types which can have unique values.
+var uniqueAttributeTypes = []string{
+	"pim_catalog_identifier",
+	"pim_catalog_text",
+	"pim_catalog_number",
+	"pim_catalog_date",
+}
+
+var optionAttributeTypes = []string{
+	"pim_catalog_simpleselect",
+	"pim_catalog_multiselect",
+}
+
+func emptyAttribute() map[string]any {
+	return map[string]any{
+		"code":                   "",
+		"type":                   nil,
+		"group":                  nil,
+		"unique":                 false,
+		"useable_as_grid_filter": false,
+		"allowed_extensions":     []any{},
+		"metric_family":          nil,
+		"default_metric_unit":    nil,
+		"reference_data_name":    nil,
+		"available_locales":      []any{},
+		"max_characters":         nil,
+		"validation_rule":        nil,
+		"validation_regexp":      nil,
+		"wysiwyg_enabled":        nil,
+		"number_min":             nil,
+		"number_max":             nil,
+		"decimals_allowed":       nil,
+		"negative_allowed":       nil,
+		"date_min":               nil,
+		"date_max":               nil,
+		"max_file_size":          nil,
+		"minimum_input_length":   nil,
+		"sort_order":             0,
+		"localizable":            false,
+		"scopable":               false,
+		"default_value":          nil,
+		"table_configuration":    nil,
+		"labels":                 map[string]any{},
+	}
+}
+
+func (s *Server) routeAttributes(mux *http.ServeMux) {
+	attributes := &resource{
+		s:          s,
+		name:       "Attribute",
+		properties: append(mapKeys(emptyAttribute()), "guidelines", "auto_option_sorting"),
+		readOnly:   []string{"group_labels", "is_main_identifier"},
+		immutable:  []string{"type", "unique", "localizable", "scopable", "metric_family"},
+		searchable: []string{"code", "type"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.attributes, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return emptyAttribute()
+		},
+		validate: s.validateAttribute,
+		saved:    s.attributeSaved,
+		normalize: func(r *http.Request, item map[string]any) map[string]any {
+			item["group_labels"] = map[string]any{}
+			if group, ok := s.attributeGroups.get(fmt.Sprint(item["group"])); ok {
+				item["group_labels"] = group["labels"]
+			}
+			return item
+		},
+	}
+	attributes.route(mux, restPrefix+"/attributes", true, false)
+
+	groups := &resource{
+		s:          s,
+		name:       "Attribute group",
+		properties: []string{"code", "sort_order", "attributes", "labels"},
+		searchable: []string{"code"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.attributeGroups, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":       "",
+				"sort_order": 0,
+				"attributes": []any{},
+				"labels":     map[string]any{},
+			}
+		},
+		validate: func(r *http.Request, item, prior map[string]any) []violation {
+			var violations []violation
+			for _, code := range stringList(item["attributes"]) {
+				if _, ok := s.attributes.get(code); !ok {
+					violations = append(violations, violation{
+						Property: "attributes",
+						Message:  fmt.Sprintf("The attribute \"%s\" does not exist.", code),
+					})
+				}
+			}
+			return violations
+		},
+		saved: s.attributeGroupSaved,
+	}
+	groups.route(mux, restPrefix+"/attribute-groups", true, false)
+
+	options := &resource{
+		s:          s,
+		name:       "Attribute option",
+		properties: []string{"code", "attribute", "sort_order", "labels"},
+		searchable: []string{"code"},
+		collection: s.attributeOptionCollection,
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":       "",
+				"attribute":  r.PathValue("attribute"),
+				"sort_order": nil,
+				"labels":     map[string]any{},
+			}
+		},
+		validate: func(r *http.Request, item, prior map[string]any) []violation {
+			if item["attribute"] != r.PathValue("attribute") {
+				return []violation{{
+					Property: "attribute",
+					Message:  fmt.Sprintf("The attribute code \"%v\" provided in the request body must match the attribute code \"%s\" provided in the url.", item["attribute"], r.PathValue("attribute")),
+				}}
+			}
+			return nil
+		},
+	}
+	options.route(mux, restPrefix+"/attributes/{attribute}/options", true, true)
+}
+
+func (s *Server) validateAttribute(r *http.Request, item, prior map[string]any) []violation {
+	var violations []violation
+
+	attributeType, _ := item["type"].(string)
+	if !slices.Contains(attributeTypes, attributeType) {
+		return []violation{{Property: "type", Message: fmt.Sprintf("The attribute type \"%v\" does not exist.", item["type"])}}
+	}
+
+	if isEmpty(item["group"]) {
+		violations = append(violations, violation{Property: "group", Message: "This value should not be blank."})
+	} else if _, ok := s.attributeGroups.get(fmt.Sprint(item["group"])); !ok {
+		violations = append(violations, violation{
+			Property: "group",
+			Message:  fmt.Sprintf("Property \"group\" expects a valid attribute group code. The attribute group does not exist, \"%v\" given.", item["group"]),
+		})
+	}
+
+	for property, types := range attributeTypeProperties {
+		if slices.Contains(types, attributeType) {
+			continue
+		}
+		if v := item[property]; v != nil && !(property == "allowed_extensions" && len(stringList(v)) == 0) {
+			violations = append(violations, violation{
+				Property: property,
+				Message:  fmt.Sprintf("This property can not be set for attributes of type \"%s\".", attributeType),
+			})
+		}
+	}
+
+	for _, property := range attributeTypeRequired[attributeType] {
+		if isEmpty(item[property]) {
+			violations = append(violations, violation{Property: property, Message: "This value should not be blank."})
+		}
+	}
+
+	if item["unique"] == true && !slices.Contains(uniqueAttributeTypes, attributeType) {
+		violations = append(violations, violation{Property: "unique", Message: "This attribute type can not have unique values."})
+	}
+
+	if attributeType == "pim_catalog_identifier" && (item["localizable"] == true || item["scopable"] == true) {
+		violations = append(violations, violation{Property: "localizable", Message: "An identifier attribute can not be localizable or scopable."})
+	}
+
+	if attributeType == "pim_catalog_metric" && !isEmpty(item["metric_family"]) {
+		family, ok := s.measurementFamilies.get(fmt.Sprint(item["metric_family"]))
+		switch {
+		case !ok:
+			violations = append(violations, violation{
+				Property: "metric_family",
+				Message:  fmt.Sprintf("Please specify a valid metric family. The metric family \"%v\" does not exist.", item["metric_family"]),
+			})
+		case !isEmpty(item["default_metric_unit"]):
+			units, _ := family["units"].(map[string]any)
+			if _, ok := units[fmt.Sprint(item["default_metric_unit"])]; !ok {
+				violations = append(violations, violation{
+					Property: "default_metric_unit",
+					Message:  fmt.Sprintf("Please specify a valid metric unit. The unit \"%v\" does not belong to the metric family \"%v\".", item["default_metric_unit"], item["metric_family"]),
+				})
+			}
+		}
+	}
+
+	for _, locale := range stringList(item["available_locales"]) {
+		if !slices.Contains(s.locales, locale) {
+			violations = append(violations, violation{
+				Property: "available_locales",
+				Message:  fmt.Sprintf("The locale \"%s\" does not exist.", locale),
+			})
+		}
+	}
+
+	if len(violations) == 0 && prior == nil {
+		// Akeneo sets the flags of the attribute type on create.
+		for property, types := range map[string][]string{
+			"wysiwyg_enabled":  attributeTypeProperties["wysiwyg_enabled"],
+			"decimals_allowed": attributeTypeProperties["decimals_allowed"],
+			"negative_allowed": attributeTypeProperties["negative_allowed"],
+		} {
+			if item[property] == nil && slices.Contains(types, attributeType) {
+				item[property] = false
+			}
+		}
+	}
+
+	return violations
+}
+
+// attributeSaved keeps the attribute list of the attribute groups in sync.
+func (s *Server) attributeSaved(r *http.Request, item, prior map[string]any) {
+	code := item["code"].(string)
+
+	if prior != nil && prior["group"] != item["group"] {
+		if group, ok := s.attributeGroups.get(fmt.Sprint(prior["group"])); ok {
+			group["attributes"] = anyList(slices.DeleteFunc(stringList(group["attributes"]), func(v string) bool { return v == code }))
+			s.attributeGroups.put(group["code"].(string), group)
+		}
+	}
+
+	if group, ok := s.attributeGroups.get(fmt.Sprint(item["group"])); ok {
+		attributes := stringList(group["attributes"])
+		if !slices.Contains(attributes, code) {
+			group["attributes"] = anyList(append(attributes, code))
+			s.attributeGroups.put(group["code"].(string), group)
+		}
+	}
+}
+
+// attributeGroupSaved moves the attributes listed by the group into it, the
+// attributes removed from the group are moved to the group "other".
+func (s *Server) attributeGroupSaved(r *http.Request, item, prior map[string]any) {
+	code := item["code"].(string)
+	attributes := stringList(item["attributes"])
+
+	for _, attributeCode := range attributes {
+		attribute, _ := s.attributes.get(attributeCode)
+		if attribute["group"] == code {
+			continue
+		}
+		if group, ok := s.attributeGroups.get(fmt.Sprint(attribute["group"])); ok {
+			group["attributes"] = anyList(slices.DeleteFunc(stringList(group["attributes"]), func(v string) bool { return v == attributeCode }))
+			s.attributeGroups.put(group["code"].(string), group)
+		}
+		attribute["group"] = code
+		s.attributes.put(attributeCode, attribute)
+	}
+
+	if prior == nil || code == "other" {
+		return
+	}
+	for _, attributeCode := range stringList(prior["attributes"]) {
+		if slices.Contains(attributes, attributeCode) {
+			continue
+		}
+		attribute, _ := s.attributes.get(attributeCode)
+		attribute["group"] = "other"
+		s.attributes.put(attributeCode, attribute)
+		s.attributeSaved(r, attribute, map[string]any{"group": code})
+	}
+}
+
+func (s *Server) attributeOptionCollection(r *http.Request) (*collection, error) {
+	code := r.PathValue("attribute")
+
+	attribute, ok := s.attributes.get(code)
+	if !ok {
+		return nil, notFound("Attribute \"%s\" does not exist.", code)
+	}
+	if !slices.Contains(optionAttributeTypes, fmt.Sprint(attribute["type"])) {
+		return nil, notFound("Attribute \"%s\" does not support options. Only attributes of type \"pim_catalog_simpleselect\", \"pim_catalog_multiselect\" support options.", code)
+	}
+
+	options, ok := s.attributeOptions[code]
+	if !ok {
+		options = newCollection()
+		s.attributeOptions[code] = options
+	}
+	return options, nil
+}
+
+func mapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,278 @@
+package akeneoxtest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+const maxTemplateAttributes = 50
+
+var categoryValueTypes = []string{"text", "textarea", "richtext", "image"}
+
+func (s *Server) routeCategories(mux *http.ServeMux) {
+	categories := &resource{
+		s:          s,
+		name:       "Category",
+		properties: []string{"code", "parent", "position", "labels", "values", "channel_requirements"},
+		readOnly:   []string{"updated"},
+		searchable: []string{"code", "parent"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.categories, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":                 "",
+				"parent":               nil,
+				"labels":               map[string]any{},
+				"values":               map[string]any{},
+				"channel_requirements": []any{},
+			}
+		},
+		validate:  s.validateCategory,
+		saved:     s.categorySaved,
+		normalize: s.normalizeCategory,
+	}
+	categories.route(mux, restPrefix+"/categories", true, false)
+
+	templates := &resource{
+		s:          s,
+		name:       "Category template",
+		properties: []string{"code", "category_tree", "labels", "attributes"},
+		immutable:  []string{"category_tree"},
+		searchable: []string{"code", "category_tree"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.categoryTemplates, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":          "",
+				"category_tree": nil,
+				"labels":        map[string]any{},
+				"attributes":    []any{},
+			}
+		},
+		validate: s.validateCategoryTemplate,
+	}
+	templates.route(mux, restPrefix+"/category-templates", true, false)
+	s.handle(mux, "DELETE "+restPrefix+"/category-templates/{code}", templates.delete)
+
+	s.handle(mux, "POST "+restPrefix+"/category-media-files", func(w http.ResponseWriter, r *http.Request) error {
+		return s.uploadMediaFile(w, r, s.categoryMediaFiles)
+	})
+}
+
+func (s *Server) validateCategory(r *http.Request, item, prior map[string]any) []violation {
+	var violations []violation
+
+	if parent := item["parent"]; parent != nil {
+		code := fmt.Sprint(parent)
+		for code != "" {
+			if code == item["code"] {
+				violations = append(violations, violation{Property: "parent", Message: "The category can not be its own parent or a parent of its parent."})
+				break
+			}
+			category, ok := s.categories.get(code)
+			if !ok {
+				violations = append(violations, violation{
+					Property: "parent",
+					Message:  fmt.Sprintf("Property \"parent\" expects a valid category code. The category does not exist, \"%v\" given.", parent),
+				})
+				break
+			}
+			code, _ = category["parent"].(string)
+		}
+	}
+
+	if position, ok := item["position"]; ok && position != nil {
+		if v, ok := position.(float64); !ok || v < 1 {
+			violations = append(violations, violation{Property: "position", Message: "The position must be a positive integer."})
+		}
+		if item["parent"] == nil {
+			violations = append(violations, violation{Property: "position", Message: "The position can not be set for a category tree."})
+		}
+	}
+
+	values, _ := item["values"].(map[string]any)
+	for key, v := range values {
+		value, _ := v.(map[string]any)
+		if value == nil {
+			violations = append(violations, violation{Property: "values", Message: fmt.Sprintf("The value \"%s\" must be an object.", key)})
+			continue
+		}
+		if !slices.Contains(categoryValueTypes, fmt.Sprint(value["type"])) {
+			violations = append(violations, violation{Property: "values", Message: fmt.Sprintf("The type \"%v\" of the value \"%s\" is not valid.", value["type"], key)})
+		}
+		if _, ok := value["data"]; !ok {
+			violations = append(violations, violation{Property: "values", Message: fmt.Sprintf("The data of the value \"%s\" is missing.", key)})
+		}
+		if channel, ok := value["channel"].(string); ok {
+			if _, exists := s.channels.get(channel); !exists {
+				violations = append(violations, violation{Property: "values", Message: fmt.Sprintf("The channel \"%s\" of the value \"%s\" does not exist.", channel, key)})
+			}
+		}
+		if locale, ok := value["locale"].(string); ok && !slices.Contains(s.locales, locale) {
+			violations = append(violations, violation{Property: "values", Message: fmt.Sprintf("The locale \"%s\" of the value \"%s\" does not exist.", locale, key)})
+		}
+	}
+
+	for _, channel := range stringList(item["channel_requirements"]) {
+		if _, ok := s.channels.get(channel); !ok {
+			violations = append(violations, violation{Property: "channel_requirements", Message: fmt.Sprintf("The channel \"%s\" does not exist.", channel)})
+		}
+	}
+
+	item["updated"] = now()
+	return violations
+}
+
+// categorySaved moves the category to its position among the children of its
+// parent. The position is not stored, it is the order of the children.
+func (s *Server) categorySaved(r *http.Request, item, prior map[string]any) {
+	position, ok := item["position"].(float64)
+	delete(item, "position")
+	s.categories.put(item["code"].(string), item)
+	if !ok {
+		return
+	}
+
+	code := item["code"].(string)
+	siblings := slices.DeleteFunc(s.categoryChildren(item["parent"]), func(v string) bool { return v == code })
+	index := min(int(position)-1, len(siblings))
+	siblings = slices.Insert(siblings, index, code)
+
+	codes := slices.DeleteFunc(s.categories.codes, func(v string) bool { return slices.Contains(siblings, v) })
+	s.categories.codes = append(codes, siblings...)
+}
+
+func (s *Server) categoryChildren(parent any) []string {
+	var children []string
+	for _, code := range s.categories.codes {
+		if s.categories.items[code]["parent"] == parent {
+			children = append(children, code)
+		}
+	}
+	return children
+}
+
+// normalizeCategory adds the position and values when they are requested.
+func (s *Server) normalizeCategory(r *http.Request, item map[string]any) map[string]any {
+	query := r.URL.Query()
+
+	if query.Get("with_position") == "true" && item["parent"] != nil {
+		item["position"] = slices.Index(s.categoryChildren(item["parent"]), item["code"].(string)) + 1
+	}
+	if query.Get("with_enriched_attributes") != "true" {
+		delete(item, "values")
+	}
+	return item
+}
+
+func (s *Server) validateCategoryTemplate(r *http.Request, item, prior map[string]any) []violation {
+	var violations []violation
+
+	tree := fmt.Sprint(item["category_tree"])
+	if category, ok := s.categories.get(tree); !ok || category["parent"] != nil {
+		violations = append(violations, violation{
+			Property: "category_tree",
+			Message:  fmt.Sprintf("Property \"category_tree\" expects a valid category tree code. The category tree does not exist, \"%v\" given.", item["category_tree"]),
+		})
+	}
+	for _, template := range s.categoryTemplates.list() {
+		if template["code"] != item["code"] && template["category_tree"] == item["category_tree"] {
+			violations = append(violations, violation{
+				Property: "category_tree",
+				Message:  fmt.Sprintf("The category tree \"%s\" already has the template \"%v\".", tree, template["code"]),
+			})
+		}
+	}
+
+	attributes, _ := item["attributes"].([]any)
+	if len(attributes) > maxTemplateAttributes {
+		violations = append(violations, violation{Property: "attributes", Message: fmt.Sprintf("A template can not have more than %d attributes.", maxTemplateAttributes)})
+	}
+
+	codes := make(map[string]bool, len(attributes))
+	for i, v := range attributes {
+		attribute, _ := v.(map[string]any)
+		property := fmt.Sprintf("attributes[%d]", i)
+		code, _ := attribute["code"].(string)
+
+		switch {
+		case code == "":
+			violations = append(violations, violation{Property: property + ".code", Message: "This value should not be blank."})
+		case codes[code]:
+			violations = append(violations, violation{Property: property + ".code", Message: fmt.Sprintf("The attribute \"%s\" is already used in the template.", code)})
+		}
+		codes[code] = true
+
+		if !slices.Contains(categoryValueTypes, fmt.Sprint(attribute["type"])) {
+			violations = append(violations, violation{Property: property + ".type", Message: fmt.Sprintf("The attribute type \"%v\" does not exist.", attribute["type"])})
+		}
+
+		for _, flag := range []string{"is_localizable", "is_scopable", "is_required"} {
+			if attribute[flag] == nil {
+				attribute[flag] = false
+			}
+		}
+		if attribute["labels"] == nil {
+			attribute["labels"] = map[string]any{}
+		}
+	}
+
+	return violations
+}
+
+// uploadMediaFile stores the file of a multipart upload and answers with the
+// media code in the Location header.
+func (s *Server) uploadMediaFile(w http.ResponseWriter, r *http.Request, files *collection) error {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return validationFailed([]violation{{Property: "file", Message: "The file is missing."}})
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return &apiError{status: http.StatusBadRequest, message: "The file could not be read."}
+	}
+
+	sum := sha1.Sum(append(content, header.Filename...))
+	hash := hex.EncodeToString(sum[:])
+	code := strings.Join([]string{hash[0:1], hash[1:2], hash[2:3], hash[3:4], hash + "_" + header.Filename}, "/")
+
+	files.put(code, map[string]any{
+		"code":              code,
+		"original_filename": header.Filename,
+		"mime_type":         mimeType(header.Filename, header.Header.Get("Content-Type")),
+		"size":              len(content),
+		"extension":         strings.TrimPrefix(path.Ext(header.Filename), "."),
+	})
+
+	s.writeSaved(w, true, r.URL.Path+"/"+code)
+	return nil
+}
+
+func mimeType(fileName string, contentType string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".pdf":
+		return "application/pdf"
+	case ".txt":
+		return "text/plain"
+	}
+	if contentType != "" && contentType != "application/octet-stream" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
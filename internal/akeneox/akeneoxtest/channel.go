@@ -0,0 +1,148 @@
+package akeneoxtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+var defaultLocales = []string{
+	"cs_CZ", "da_DK", "de_AT", "de_CH", "de_DE", "en_AU", "en_CA", "en_GB", "en_NZ", "en_US",
+	"es_ES", "es_MX", "fi_FI", "fr_BE", "fr_CA", "fr_CH", "fr_FR", "hu_HU", "it_IT", "ja_JP",
+	"ko_KR", "nl_BE", "nl_NL", "no_NO", "pl_PL", "pt_BR", "pt_PT", "ro_RO", "ru_RU", "sk_SK",
+	"sv_SE", "tr_TR", "uk_UA", "zh_CN", "zh_TW",
+}
+
+var defaultCurrencies = []string{
+	"AUD", "CAD", "CHF", "CZK", "DKK", "EUR", "GBP", "JPY", "PLN", "SEK", "USD",
+}
+
+func (s *Server) routeChannels(mux *http.ServeMux) {
+	channels := &resource{
+		s:          s,
+		name:       "Channel",
+		properties: []string{"code", "currencies", "locales", "category_tree", "conversion_units", "labels"},
+		searchable: []string{"code"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.channels, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":             "",
+				"currencies":       []any{},
+				"locales":          []any{},
+				"category_tree":    nil,
+				"conversion_units": map[string]any{},
+				"labels":           map[string]any{},
+			}
+		},
+		validate: s.validateChannel,
+		saved:    s.channelSaved,
+	}
+	channels.route(mux, restPrefix+"/channels", true, false)
+
+	s.handle(mux, "GET "+restPrefix+"/locales", s.listLocales)
+}
+
+func (s *Server) validateChannel(r *http.Request, item, prior map[string]any) []violation {
+	var violations []violation
+
+	if isEmpty(item["category_tree"]) {
+		violations = append(violations, violation{Property: "category_tree", Message: "This value should not be blank."})
+	} else if category, ok := s.categories.get(fmt.Sprint(item["category_tree"])); !ok || category["parent"] != nil {
+		violations = append(violations, violation{
+			Property: "category_tree",
+			Message:  fmt.Sprintf("Property \"category_tree\" expects a valid category tree code. The category tree does not exist, \"%v\" given.", item["category_tree"]),
+		})
+	}
+
+	locales := stringList(item["locales"])
+	if len(locales) == 0 {
+		violations = append(violations, violation{Property: "locales", Message: "This collection should contain 1 element or more."})
+	}
+	for _, locale := range locales {
+		if !slices.Contains(s.locales, locale) {
+			violations = append(violations, violation{
+				Property: "locales",
+				Message:  fmt.Sprintf("Property \"locales\" expects an array of valid locale codes. The locale does not exist, \"%s\" given.", locale),
+			})
+		}
+	}
+
+	currencies := stringList(item["currencies"])
+	if len(currencies) == 0 {
+		violations = append(violations, violation{Property: "currencies", Message: "This collection should contain 1 element or more."})
+	}
+	for _, currency := range currencies {
+		if !slices.Contains(s.currencies, currency) {
+			violations = append(violations, violation{
+				Property: "currencies",
+				Message:  fmt.Sprintf("Property \"currencies\" expects an array of valid currency codes. The currency does not exist, \"%s\" given.", currency),
+			})
+		}
+	}
+
+	units, _ := item["conversion_units"].(map[string]any)
+	for code, unit := range units {
+		attribute, ok := s.attributes.get(code)
+		if !ok || attribute["type"] != "pim_catalog_metric" {
+			violations = append(violations, violation{
+				Property: "conversion_units",
+				Message:  fmt.Sprintf("Property \"conversion_units\" expects a valid attribute code. The metric attribute does not exist, \"%s\" given.", code),
+			})
+			continue
+		}
+		family, _ := s.measurementFamilies.get(fmt.Sprint(attribute["metric_family"]))
+		familyUnits, _ := family["units"].(map[string]any)
+		if _, ok := familyUnits[fmt.Sprint(unit)]; !ok {
+			violations = append(violations, violation{
+				Property: "conversion_units",
+				Message:  fmt.Sprintf("Property \"conversion_units\" expects a valid unit code. The unit \"%v\" does not exist in the measurement family of the attribute \"%s\".", unit, code),
+			})
+		}
+	}
+
+	return violations
+}
+
+// channelSaved requires the identifier attribute of every family for a new channel.
+func (s *Server) channelSaved(r *http.Request, item, prior map[string]any) {
+	if prior != nil {
+		return
+	}
+
+	for _, family := range s.families.list() {
+		requirements, _ := family["attribute_requirements"].(map[string]any)
+		if requirements == nil {
+			requirements = make(map[string]any)
+		}
+		requirements[item["code"].(string)] = []any{IdentifierAttribute}
+		family["attribute_requirements"] = requirements
+		s.families.put(family["code"].(string), family)
+	}
+}
+
+// listLocales lists the locales, a locale is enabled when a channel uses it.
+func (s *Server) listLocales(w http.ResponseWriter, r *http.Request) error {
+	search, err := parseSearch(r, []string{"code", "enabled"})
+	if err != nil {
+		return err
+	}
+
+	enabled := make(map[string]bool)
+	for _, channel := range s.channels.list() {
+		for _, locale := range stringList(channel["locales"]) {
+			enabled[locale] = true
+		}
+	}
+
+	items := make([]map[string]any, 0)
+	for _, locale := range s.locales {
+		item := map[string]any{"code": locale, "enabled": enabled[locale]}
+		if search.match(item) {
+			items = append(items, item)
+		}
+	}
+
+	return s.writeList(w, r, items)
+}
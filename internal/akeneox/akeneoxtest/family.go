@@ -0,0 +1,234 @@
+package akeneoxtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+const maxVariantAxes = 5
+
+// variantAxisTypes are the attribute types which can be variant axes.
+var variantAxisTypes = []string{
+	"pim_catalog_simpleselect",
+	"akeneo_reference_entity",
+	"pim_catalog_metric",
+	"pim_catalog_boolean",
+	"pim_reference_data_simpleselect",
+}
+
+func (s *Server) routeFamilies(mux *http.ServeMux) {
+	families := &resource{
+		s:          s,
+		name:       "Family",
+		properties: []string{"code", "attribute_as_label", "attribute_as_image", "attributes", "attribute_requirements", "labels"},
+		searchable: []string{"code"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.families, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":                   "",
+				"attribute_as_label":     IdentifierAttribute,
+				"attribute_as_image":     nil,
+				"attributes":             []any{IdentifierAttribute},
+				"attribute_requirements": map[string]any{},
+				"labels":                 map[string]any{},
+			}
+		},
+		validate: s.validateFamily,
+	}
+	families.route(mux, restPrefix+"/families", true, false)
+
+	variants := &resource{
+		s:          s,
+		name:       "Family variant",
+		properties: []string{"code", "variant_attribute_sets", "labels"},
+		readOnly:   []string{"family"},
+		searchable: []string{"code"},
+		collection: s.familyVariantCollection,
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":                   "",
+				"variant_attribute_sets": []any{},
+				"labels":                 map[string]any{},
+			}
+		},
+		validate: s.validateFamilyVariant,
+	}
+	variants.route(mux, restPrefix+"/families/{family}/variants", true, false)
+}
+
+func (s *Server) validateFamily(r *http.Request, item, prior map[string]any) []violation {
+	var violations []violation
+
+	// The identifier attribute is part of every family.
+	attributes := stringList(item["attributes"])
+	if !slices.Contains(attributes, IdentifierAttribute) {
+		attributes = append([]string{IdentifierAttribute}, attributes...)
+	}
+	item["attributes"] = anyList(attributes)
+
+	types := make(map[string]string, len(attributes))
+	for _, code := range attributes {
+		attribute, ok := s.attributes.get(code)
+		if !ok {
+			violations = append(violations, violation{
+				Property: "attributes",
+				Message:  fmt.Sprintf("Property \"attributes\" expects a valid attribute code. The attribute does not exist, \"%s\" given.", code),
+			})
+			continue
+		}
+		types[code] = fmt.Sprint(attribute["type"])
+	}
+
+	if label := fmt.Sprint(item["attribute_as_label"]); !isEmpty(item["attribute_as_label"]) {
+		switch {
+		case !slices.Contains(attributes, label):
+			violations = append(violations, violation{Property: "attribute_as_label", Message: "Property \"attribute_as_label\" must belong to the family."})
+		case types[label] != "pim_catalog_text" && types[label] != "pim_catalog_identifier":
+			violations = append(violations, violation{Property: "attribute_as_label", Message: "Property \"attribute_as_label\" only supports \"pim_catalog_text\" and \"pim_catalog_identifier\" attribute types for the family."})
+		}
+	}
+
+	if image := fmt.Sprint(item["attribute_as_image"]); !isEmpty(item["attribute_as_image"]) {
+		switch {
+		case !slices.Contains(attributes, image):
+			violations = append(violations, violation{Property: "attribute_as_image", Message: "Property \"attribute_as_image\" must belong to the family."})
+		case types[image] != "pim_catalog_image" && types[image] != "pim_catalog_asset_collection":
+			violations = append(violations, violation{Property: "attribute_as_image", Message: "Property \"attribute_as_image\" only supports \"pim_catalog_image\" and \"pim_catalog_asset_collection\" attribute types for the family."})
+		}
+	}
+
+	configured, _ := item["attribute_requirements"].(map[string]any)
+	for channel, v := range configured {
+		if _, ok := s.channels.get(channel); !ok {
+			violations = append(violations, violation{
+				Property: "attribute_requirements",
+				Message:  fmt.Sprintf("Property \"attribute_requirements\" expects a valid channel code. The channel does not exist, \"%s\" given.", channel),
+			})
+			continue
+		}
+		for _, code := range stringList(v) {
+			if !slices.Contains(attributes, code) {
+				violations = append(violations, violation{
+					Property: "attribute_requirements",
+					Message:  fmt.Sprintf("The attribute \"%s\" cannot be a requirement of the channel \"%s\", it does not belong to the family.", code, channel),
+				})
+			}
+		}
+	}
+
+	// The identifier attribute is required for every channel.
+	requirements := make(map[string]any, len(s.channels.codes))
+	for _, code := range s.channels.codes {
+		required := stringList(configured[code])
+		if !slices.Contains(required, IdentifierAttribute) {
+			required = append([]string{IdentifierAttribute}, required...)
+		}
+		requirements[code] = anyList(required)
+	}
+	item["attribute_requirements"] = requirements
+
+	return violations
+}
+
+func (s *Server) familyVariantCollection(r *http.Request) (*collection, error) {
+	code := r.PathValue("family")
+
+	if _, ok := s.families.get(code); !ok {
+		return nil, notFound("Family \"%s\" does not exist.", code)
+	}
+
+	variants, ok := s.familyVariants[code]
+	if !ok {
+		variants = newCollection()
+		s.familyVariants[code] = variants
+	}
+	return variants, nil
+}
+
+func (s *Server) validateFamilyVariant(r *http.Request, item, prior map[string]any) []violation {
+	family, _ := s.families.get(r.PathValue("family"))
+	familyAttributes := stringList(family["attributes"])
+
+	sets, _ := item["variant_attribute_sets"].([]any)
+	if len(sets) == 0 || len(sets) > 2 {
+		return []violation{{Property: "variant_attribute_sets", Message: "A family variant must have one or two enrichment levels."}}
+	}
+
+	var violations []violation
+	var priorSets []any
+	if prior != nil {
+		priorSets, _ = prior["variant_attribute_sets"].([]any)
+	}
+
+	used := make(map[string]bool)
+	normalized := make([]any, len(sets))
+	for _, v := range sets {
+		set, _ := v.(map[string]any)
+		level, _ := set["level"].(float64)
+		index := int(level) - 1
+		if index < 0 || index >= len(sets) || normalized[index] != nil {
+			return []violation{{Property: "variant_attribute_sets", Message: "The enrichment levels must be numbered from 1 without gaps."}}
+		}
+		prefix := "variant_attribute_sets[" + strconv.Itoa(index+1) + "]"
+
+		axes := stringList(set["axes"])
+		if len(axes) == 0 {
+			violations = append(violations, violation{Property: prefix + ".axes", Message: "A variant attribute set must have at least one axis."})
+		}
+		if len(axes) > maxVariantAxes {
+			violations = append(violations, violation{Property: prefix + ".axes", Message: fmt.Sprintf("A variant attribute set can not have more than %d axes.", maxVariantAxes)})
+		}
+		if index < len(priorSets) {
+			if priorAxes := stringList(priorSets[index].(map[string]any)["axes"]); !slices.Equal(priorAxes, axes) {
+				violations = append(violations, violation{Property: prefix + ".axes", Message: "The axes of a variant attribute set can not be changed."})
+			}
+		}
+
+		for _, code := range axes {
+			attribute, ok := s.attributes.get(code)
+			switch {
+			case !ok || !slices.Contains(familyAttributes, code):
+				violations = append(violations, violation{Property: prefix + ".axes", Message: fmt.Sprintf("The attribute \"%s\" does not belong to the family.", code)})
+			case !slices.Contains(variantAxisTypes, fmt.Sprint(attribute["type"])):
+				violations = append(violations, violation{Property: prefix + ".axes", Message: fmt.Sprintf("The attribute \"%s\" of type \"%v\" can not be a variant axis.", code, attribute["type"])})
+			case attribute["localizable"] == true || attribute["scopable"] == true:
+				violations = append(violations, violation{Property: prefix + ".axes", Message: fmt.Sprintf("The attribute \"%s\" can not be a variant axis, it is localizable or scopable.", code)})
+			}
+		}
+
+		// Akeneo adds the axes to the attributes of their level, and the
+		// identifier to the last level.
+		attributes := stringList(set["attributes"])
+		for _, code := range axes {
+			if !slices.Contains(attributes, code) {
+				attributes = append(attributes, code)
+			}
+		}
+		if index == len(sets)-1 && !slices.Contains(attributes, IdentifierAttribute) {
+			attributes = append(attributes, IdentifierAttribute)
+		}
+
+		for _, code := range attributes {
+			if !slices.Contains(familyAttributes, code) {
+				violations = append(violations, violation{Property: prefix + ".attributes", Message: fmt.Sprintf("The attribute \"%s\" does not belong to the family.", code)})
+			}
+			if used[code] {
+				violations = append(violations, violation{Property: prefix + ".attributes", Message: fmt.Sprintf("The attribute \"%s\" can only be used in a single enrichment level.", code)})
+			}
+			used[code] = true
+		}
+
+		normalized[index] = map[string]any{
+			"level":      index + 1,
+			"axes":       anyList(axes),
+			"attributes": anyList(attributes),
+		}
+	}
+	item["variant_attribute_sets"] = normalized
+
+	return violations
+}
@@ -0,0 +1,227 @@
+package akeneoxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+const maxMeasurementUnits = 50
+
+var conversionOperators = []string{"mul", "div", "add", "sub"}
+
+var defaultMeasurementFamilies = []map[string]any{
+	measurementFamily("Length", "METER", map[string]string{"en_US": "Length"},
+		measurementUnit("METER", "m", "mul", "1", map[string]string{"en_US": "Meter"}),
+		measurementUnit("CENTIMETER", "cm", "mul", "0.01", map[string]string{"en_US": "Centimeter"}),
+		measurementUnit("MILLIMETER", "mm", "mul", "0.001", map[string]string{"en_US": "Millimeter"}),
+		measurementUnit("KILOMETER", "km", "mul", "1000", map[string]string{"en_US": "Kilometer"}),
+	),
+	measurementFamily("Weight", "GRAM", map[string]string{"en_US": "Weight"},
+		measurementUnit("GRAM", "g", "mul", "1", map[string]string{"en_US": "Gram"}),
+		measurementUnit("MILLIGRAM", "mg", "mul", "0.001", map[string]string{"en_US": "Milligram"}),
+		measurementUnit("KILOGRAM", "kg", "mul", "1000", map[string]string{"en_US": "Kilogram"}),
+	),
+}
+
+func measurementFamily(code, standardUnit string, labels map[string]string, units ...map[string]any) map[string]any {
+	family := map[string]any{
+		"code":               code,
+		"labels":             labels,
+		"standard_unit_code": standardUnit,
+		"units":              map[string]any{},
+	}
+	for _, unit := range units {
+		family["units"].(map[string]any)[unit["code"].(string)] = unit
+	}
+	return deepCopy(family)
+}
+
+func measurementUnit(code, symbol, operator, value string, labels map[string]string) map[string]any {
+	return map[string]any{
+		"code":   code,
+		"labels": labels,
+		"convert_from_standard": []any{
+			map[string]any{"operator": operator, "value": value},
+		},
+		"symbol": symbol,
+	}
+}
+
+func (s *Server) routeMeasurementFamilies(mux *http.ServeMux) {
+	s.handle(mux, "GET "+restPrefix+"/measurement-families", func(w http.ResponseWriter, r *http.Request) error {
+		writeJSON(w, http.StatusOK, s.measurementFamilies.list())
+		return nil
+	})
+	s.handle(mux, "PATCH "+restPrefix+"/measurement-families", s.updateMeasurementFamilies)
+}
+
+// updateMeasurementFamilies creates or replaces the measurement families of
+// the request and answers with the status of each family.
+func (s *Server) updateMeasurementFamilies(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errInvalidJSON
+	}
+
+	var families []map[string]any
+	if err := json.Unmarshal(body, &families); err != nil {
+		return errInvalidJSON
+	}
+	if len(families) > maxPageSize {
+		return &apiError{
+			status:  http.StatusRequestEntityTooLarge,
+			message: fmt.Sprintf("Too many resources to process, %d is the maximum allowed.", maxPageSize),
+		}
+	}
+
+	statuses := make([]map[string]any, len(families))
+	for i, family := range families {
+		code, _ := family["code"].(string)
+		status := map[string]any{"code": code}
+
+		prior, exists := s.measurementFamilies.get(code)
+		violations := s.validateMeasurementFamily(family, prior)
+		switch {
+		case len(violations) > 0:
+			status["status_code"] = http.StatusUnprocessableEntity
+			status["message"] = "The measurement family has data that does not comply with the business rules."
+			status["errors"] = violations
+		case exists:
+			status["status_code"] = http.StatusNoContent
+		default:
+			status["status_code"] = http.StatusCreated
+		}
+
+		if len(violations) == 0 {
+			mergeMeasurementLabels(family, prior)
+			s.measurementFamilies.put(code, family)
+		}
+		statuses[i] = status
+	}
+
+	writeJSON(w, http.StatusOK, statuses)
+	return nil
+}
+
+// mergeMeasurementLabels merges the labels of the family and its units into
+// the prior labels like Akeneo does, an empty label removes the locale.
+func mergeMeasurementLabels(family, prior map[string]any) {
+	family["labels"] = mergeLabels(prior["labels"], family["labels"])
+
+	units, _ := family["units"].(map[string]any)
+	priorUnits, _ := prior["units"].(map[string]any)
+	for code, v := range units {
+		unit, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		priorUnit, _ := priorUnits[code].(map[string]any)
+		unit["labels"] = mergeLabels(priorUnit["labels"], unit["labels"])
+	}
+}
+
+func mergeLabels(prior, labels any) map[string]any {
+	merged := make(map[string]any)
+	if priorLabels, ok := prior.(map[string]any); ok {
+		for locale, label := range priorLabels {
+			merged[locale] = label
+		}
+	}
+	if labels, ok := labels.(map[string]any); ok {
+		for locale, label := range labels {
+			if label == "" {
+				delete(merged, locale)
+				continue
+			}
+			merged[locale] = label
+		}
+	}
+	return merged
+}
+
+func (s *Server) validateMeasurementFamily(family, prior map[string]any) []violation {
+	for property := range family {
+		if !slices.Contains([]string{"code", "labels", "standard_unit_code", "units"}, property) {
+			return []violation{{Property: property, Message: fmt.Sprintf("Property \"%s\" does not exist.", property)}}
+		}
+	}
+
+	code, _ := family["code"].(string)
+	if code == "" {
+		return []violation{{Property: "code", Message: "This value should not be blank."}}
+	}
+
+	var violations []violation
+
+	units, _ := family["units"].(map[string]any)
+	if len(units) == 0 {
+		violations = append(violations, violation{Property: "units", Message: "The measurement family must have at least one unit."})
+	}
+	if len(units) > maxMeasurementUnits {
+		violations = append(violations, violation{Property: "units", Message: fmt.Sprintf("The measurement family can not have more than %d units.", maxMeasurementUnits)})
+	}
+
+	standardUnit, _ := family["standard_unit_code"].(string)
+	if _, ok := units[standardUnit]; !ok {
+		violations = append(violations, violation{Property: "standard_unit_code", Message: fmt.Sprintf("The standard unit \"%s\" is not a unit of the measurement family.", standardUnit)})
+	}
+
+	for unitCode, v := range units {
+		unit, _ := v.(map[string]any)
+		property := "units[" + unitCode + "]"
+		if unit["code"] != unitCode {
+			violations = append(violations, violation{Property: property + ".code", Message: "The unit code must match the key of the unit."})
+		}
+
+		conversions, _ := unit["convert_from_standard"].([]any)
+		if len(conversions) == 0 {
+			violations = append(violations, violation{Property: property + ".convert_from_standard", Message: "The unit must have at least one conversion operation."})
+		}
+		for _, c := range conversions {
+			conversion, _ := c.(map[string]any)
+			if !slices.Contains(conversionOperators, fmt.Sprint(conversion["operator"])) {
+				violations = append(violations, violation{Property: property + ".convert_from_standard", Message: fmt.Sprintf("The operator \"%v\" is not valid.", conversion["operator"])})
+			}
+			value, _ := conversion["value"].(string)
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				violations = append(violations, violation{Property: property + ".convert_from_standard", Message: fmt.Sprintf("The value \"%v\" is not a number.", conversion["value"])})
+			}
+		}
+
+		if unitCode == standardUnit && !equal(conversions, []any{map[string]any{"operator": "mul", "value": "1"}}) {
+			violations = append(violations, violation{Property: property + ".convert_from_standard", Message: "The standard unit must be converted by a multiplication by 1."})
+		}
+	}
+
+	if prior == nil || len(violations) > 0 {
+		return violations
+	}
+
+	// The units of a measurement family used by attributes are locked.
+	used := false
+	for _, attribute := range s.attributes.list() {
+		if attribute["type"] == "pim_catalog_metric" && attribute["metric_family"] == code {
+			used = true
+			break
+		}
+	}
+	if !used {
+		return nil
+	}
+
+	if prior["standard_unit_code"] != standardUnit {
+		violations = append(violations, violation{Property: "standard_unit_code", Message: "The standard unit of a measurement family used by attributes can not be changed."})
+	}
+	priorUnits, _ := prior["units"].(map[string]any)
+	for unitCode := range priorUnits {
+		if _, ok := units[unitCode]; !ok {
+			violations = append(violations, violation{Property: "units", Message: fmt.Sprintf("The unit \"%s\" of a measurement family used by attributes can not be removed.", unitCode)})
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,208 @@
+package akeneoxtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+func (s *Server) routeProducts(mux *http.ServeMux) {
+	productModels := &resource{
+		s:          s,
+		name:       "Product model",
+		properties: []string{"code", "family", "family_variant", "parent", "categories", "values", "associations", "quantified_associations"},
+		readOnly:   []string{"created", "updated", "metadata", "quality_scores"},
+		immutable:  []string{"family", "family_variant"},
+		searchable: []string{"code", "family", "parent"},
+		collection: func(r *http.Request) (*collection, error) {
+			return s.productModels, nil
+		},
+		empty: func(r *http.Request) map[string]any {
+			return map[string]any{
+				"code":                    "",
+				"family":                  nil,
+				"family_variant":          nil,
+				"parent":                  nil,
+				"categories":              []any{},
+				"values":                  map[string]any{},
+				"associations":            map[string]any{},
+				"quantified_associations": map[string]any{},
+				"created":                 now(),
+			}
+		},
+		validate: s.validateProductModel,
+	}
+	productModels.route(mux, restPrefix+"/product-models", true, false)
+	s.handle(mux, "DELETE "+restPrefix+"/product-models/{code}", productModels.delete)
+
+	s.handle(mux, "POST "+restPrefix+"/media-files", func(w http.ResponseWriter, r *http.Request) error {
+		return s.uploadMediaFile(w, r, s.mediaFiles)
+	})
+	s.handle(mux, "GET "+restPrefix+"/media-files/{code...}", func(w http.ResponseWriter, r *http.Request) error {
+		code := r.PathValue("code")
+		file, ok := s.mediaFiles.get(code)
+		if !ok {
+			return notFound("Media file \"%s\" does not exist.", code)
+		}
+		file["_links"] = map[string]any{
+			"download": map[string]any{"href": s.URL + r.URL.Path + "/download"},
+		}
+		writeJSON(w, http.StatusOK, file)
+		return nil
+	})
+}
+
+func (s *Server) validateProductModel(r *http.Request, item, prior map[string]any) []violation {
+	var violations []violation
+
+	// A sub product model inherits the family of its parent.
+	if parent, ok := item["parent"].(string); ok && parent != "" {
+		model, exists := s.productModels.get(parent)
+		switch {
+		case !exists:
+			return []violation{{Property: "parent", Message: fmt.Sprintf("Property \"parent\" expects a valid product model code. The product model does not exist, \"%s\" given.", parent)}}
+		case model["parent"] != nil:
+			return []violation{{Property: "parent", Message: fmt.Sprintf("The product model \"%s\" can not be the parent of a product model, it is a sub product model.", parent)}}
+		}
+		if item["family_variant"] != nil && item["family_variant"] != model["family_variant"] {
+			violations = append(violations, violation{Property: "family_variant", Message: "The family variant of a sub product model must be the family variant of its parent."})
+		}
+		item["family"] = model["family"]
+		item["family_variant"] = model["family_variant"]
+	}
+
+	family := fmt.Sprint(item["family"])
+	if isEmpty(item["family"]) {
+		return append(violations, violation{Property: "family", Message: "This value should not be blank."})
+	}
+	if _, ok := s.families.get(family); !ok {
+		return append(violations, violation{Property: "family", Message: fmt.Sprintf("Property \"family\" expects a valid family code. The family does not exist, \"%s\" given.", family)})
+	}
+
+	variantCode := fmt.Sprint(item["family_variant"])
+	if isEmpty(item["family_variant"]) {
+		return append(violations, violation{Property: "family_variant", Message: "This value should not be blank."})
+	}
+	variant, ok := s.familyVariants[family].items[variantCode]
+	if s.familyVariants[family] == nil || !ok {
+		return append(violations, violation{Property: "family_variant", Message: fmt.Sprintf("Property \"family_variant\" expects a valid family variant code. The family variant does not exist, \"%s\" given.", variantCode)})
+	}
+
+	// The level of an attribute is 0 for common attributes, else the level of
+	// its variant attribute set.
+	levels := make(map[string]int)
+	sets, _ := variant["variant_attribute_sets"].([]any)
+	for _, v := range sets {
+		set, _ := v.(map[string]any)
+		level, _ := set["level"].(float64)
+		for _, code := range stringList(set["attributes"]) {
+			levels[code] = int(level)
+		}
+	}
+
+	level := 0
+	if item["parent"] != nil {
+		level = 1
+		if len(sets) < 2 {
+			violations = append(violations, violation{Property: "parent", Message: fmt.Sprintf("The family variant \"%s\" has only one level, its product models can not have a parent.", variantCode)})
+		}
+	}
+
+	for _, category := range stringList(item["categories"]) {
+		if _, ok := s.categories.get(category); !ok {
+			violations = append(violations, violation{Property: "categories", Message: fmt.Sprintf("Property \"categories\" expects a valid category code. The category does not exist, \"%s\" given.", category)})
+		}
+	}
+
+	familyAttributes := stringList(s.families.items[family]["attributes"])
+	values, _ := item["values"].(map[string]any)
+	var priorValues map[string]any
+	if prior != nil {
+		priorValues, _ = prior["values"].(map[string]any)
+	}
+	for code, v := range values {
+		attribute, ok := s.attributes.get(code)
+		if !ok || !slices.Contains(familyAttributes, code) {
+			violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("The attribute \"%s\" does not belong to the family \"%s\".", code, family)})
+			continue
+		}
+		if levels[code] != level {
+			violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("Cannot set the property \"%s\" to this entity as it is not in the attribute set.", code)})
+			continue
+		}
+
+		list := mergeValues(priorValues[code], v)
+		for _, value := range list {
+			violations = append(violations, s.validateValue(code, attribute, value)...)
+		}
+		if len(list) == 0 {
+			delete(values, code)
+		} else {
+			values[code] = list
+		}
+	}
+
+	item["updated"] = now()
+	return violations
+}
+
+// mergeValues merges the values of an attribute by locale and scope, the way
+// Akeneo applies PATCH requests. A value with null data removes the value.
+func mergeValues(prior, values any) []any {
+	key := func(v any) string {
+		value, _ := v.(map[string]any)
+		return fmt.Sprintf("%v/%v", value["locale"], value["scope"])
+	}
+
+	merged := make([]any, 0)
+	updated := make(map[string]bool)
+	for _, v := range values.([]any) {
+		updated[key(v)] = true
+	}
+	if priorList, ok := prior.([]any); ok {
+		for _, v := range priorList {
+			if !updated[key(v)] {
+				merged = append(merged, v)
+			}
+		}
+	}
+	for _, v := range values.([]any) {
+		if value, _ := v.(map[string]any); value["data"] != nil {
+			merged = append(merged, v)
+		}
+	}
+	return merged
+}
+
+func (s *Server) validateValue(code string, attribute map[string]any, v any) []violation {
+	value, _ := v.(map[string]any)
+	if value == nil {
+		return []violation{{Property: "values", Attribute: code, Message: "The value must be an object."}}
+	}
+
+	var violations []violation
+
+	locale, _ := value["locale"].(string)
+	switch {
+	case attribute["localizable"] == true && locale == "":
+		violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("The %s attribute requires a locale.", code)})
+	case attribute["localizable"] != true && locale != "":
+		violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("The %s attribute does not require a locale, \"%s\" was detected.", code, locale)})
+	case locale != "" && !slices.Contains(s.locales, locale):
+		violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("The %s attribute requires an existing locale, \"%s\" was detected.", code, locale)})
+	}
+
+	scope, _ := value["scope"].(string)
+	switch {
+	case attribute["scopable"] == true && scope == "":
+		violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("The %s attribute requires a channel.", code)})
+	case attribute["scopable"] != true && scope != "":
+		violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("The %s attribute does not require a channel, \"%s\" was detected.", code, scope)})
+	case scope != "":
+		if _, ok := s.channels.get(scope); !ok {
+			violations = append(violations, violation{Property: "values", Attribute: code, Message: fmt.Sprintf("The %s attribute requires an existing channel, \"%s\" was detected.", code, scope)})
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,362 @@
+package akeneoxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay answers the requests from the fixture without network access.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests to the real API and saves them to the fixture.
+	ModeRecord
+)
+
+// RecordedHost replaces the host of the recorded API in the fixtures, so the
+// fixtures do not reveal which PIM they were recorded against.
+const RecordedHost = "akeneo.example.com"
+
+// Redacted replaces the credentials and tokens in the fixtures.
+const Redacted = "REDACTED"
+
+// fixtureVersion is the version of the fixture format.
+const fixtureVersion = 1
+
+// redactedFields are the JSON fields of the token requests and responses
+// replaced by Redacted.
+var redactedFields = []string{"username", "password", "access_token", "refresh_token"}
+
+// recordedHeaders are the headers kept in the fixtures, all others are dropped.
+// Notably the Authorization header is never recorded.
+var recordedHeaders = []string{"Content-Type", "Location", "Retry-After"}
+
+// Fixture is the YAML document of the recorded interactions.
+type Fixture struct {
+	Version      int           `yaml:"version"`
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Interaction is a recorded request and the response of the API.
+type Interaction struct {
+	Request  RecordedRequest  `yaml:"request"`
+	Response RecordedResponse `yaml:"response"`
+}
+
+// RecordedRequest is the sanitized request of an interaction.
+type RecordedRequest struct {
+	Method  string      `yaml:"method"`
+	URL     string      `yaml:"url"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized response of an interaction.
+type RecordedResponse struct {
+	StatusCode int         `yaml:"status_code"`
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording the interactions with a real
+// Akeneo PIM to a YAML fixture, or replaying them from it. It is plugged into
+// the client with akeneox.WithHTTPClient and Recorder.Client.
+//
+// Recorded fixtures are sanitized: the Authorization header is dropped, the
+// credentials and tokens of the token endpoint are replaced by Redacted and
+// the host of the API is replaced by RecordedHost.
+//
+// Requests are replayed by method, path, query and body in the recorded order,
+// so repeated requests receive the responses in the order they were recorded.
+// The credentials and tokens, and the bodies of multipart requests, are not
+// compared.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	fixture Fixture
+	used    []bool
+}
+
+// NewRecorder creates a recorder for the fixture at path. In ModeReplay the
+// fixture is loaded from path, in ModeRecord it is written there by Stop.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		fixture:   Fixture{Version: fixtureVersion},
+	}
+
+	if mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read fixture: %w", err)
+		}
+		if err := yaml.Unmarshal(b, &r.fixture); err != nil {
+			return nil, fmt.Errorf("unable to decode fixture %s: %w", path, err)
+		}
+		if r.fixture.Version != fixtureVersion {
+			return nil, fmt.Errorf("unsupported fixture version %d in %s", r.fixture.Version, path)
+		}
+		r.used = make([]bool, len(r.fixture.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an HTTP client using the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the recorded interactions to the fixture in ModeRecord.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := yaml.Marshal(r.fixture)
+	if err != nil {
+		return fmt.Errorf("unable to encode fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("unable to write fixture: %w", err)
+	}
+	if err := os.WriteFile(r.path, b, 0o644); err != nil {
+		return fmt.Errorf("unable to write fixture: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := req.URL.Scheme + "://" + req.URL.Host
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     sanitizeHost(req.URL.String(), origin),
+			Headers: recordHeaders(req.Header, origin),
+			Body:    sanitizeBody(req.Header.Get("Content-Type"), body, origin),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Headers:    recordHeaders(res.Header, origin),
+			Body:       sanitizeBody(res.Header.Get("Content-Type"), respBody, origin),
+		},
+	}
+
+	r.mu.Lock()
+	r.fixture.Interactions = append(r.fixture.Interactions, interaction)
+	r.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(respBody))
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.fixture.Interactions {
+		if r.used[i] || !matches(interaction.Request, req, body) {
+			continue
+		}
+		r.used[i] = true
+
+		origin := req.URL.Scheme + "://" + req.URL.Host
+		header := http.Header{}
+		for name, values := range interaction.Response.Headers {
+			for _, v := range values {
+				header.Add(name, strings.ReplaceAll(v, "https://"+RecordedHost, origin))
+			}
+		}
+		respBody := strings.ReplaceAll(interaction.Response.Body, "https://"+RecordedHost, origin)
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s in %s", req.Method, req.URL.RequestURI(), r.path)
+}
+
+// Unused returns the recorded interactions which were not replayed, tests can
+// use it to check that the client sent every recorded request.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.fixture.Interactions[i])
+		}
+	}
+	return unused
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func matches(recorded RecordedRequest, req *http.Request, body []byte) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+
+	u, err := url.Parse(recorded.URL)
+	if err != nil || u.Path != req.URL.Path || !reflect.DeepEqual(u.Query(), req.URL.Query()) {
+		return false
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if isMultipart(contentType) {
+		return true
+	}
+	return equalBodies(recorded.Body, sanitizeBody(contentType, body, ""))
+}
+
+// equalBodies compares JSON bodies, or each line of collection bodies, by
+// value, so the order of the object keys does not matter.
+func equalBodies(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	aLines := strings.Split(strings.TrimSpace(a), "\n")
+	bLines := strings.Split(strings.TrimSpace(b), "\n")
+	if len(aLines) != len(bLines) {
+		return false
+	}
+
+	for i := range aLines {
+		var aValue, bValue any
+		if json.Unmarshal([]byte(aLines[i]), &aValue) != nil || json.Unmarshal([]byte(bLines[i]), &bValue) != nil {
+			return false
+		}
+		if !reflect.DeepEqual(aValue, bValue) {
+			return false
+		}
+	}
+	return true
+}
+
+func recordHeaders(header http.Header, origin string) http.Header {
+	recorded := http.Header{}
+	for _, name := range recordedHeaders {
+		for _, v := range header.Values(name) {
+			recorded.Add(name, sanitizeHost(v, origin))
+		}
+	}
+	if len(recorded) == 0 {
+		return nil
+	}
+	return recorded
+}
+
+// sanitizeBody replaces the host and the credentials and tokens in the body.
+// Multipart bodies carry the uploaded files and are not recorded.
+func sanitizeBody(contentType string, body []byte, origin string) string {
+	if isMultipart(contentType) {
+		return ""
+	}
+
+	s := sanitizeHost(string(body), origin)
+
+	var object map[string]any
+	if json.Unmarshal([]byte(s), &object) != nil {
+		return s
+	}
+
+	redacted := false
+	for _, field := range redactedFields {
+		if _, ok := object[field]; ok {
+			object[field] = Redacted
+			redacted = true
+		}
+	}
+	if !redacted {
+		return s
+	}
+
+	b, err := json.Marshal(object)
+	if err != nil {
+		return s
+	}
+	return string(b)
+}
+
+func sanitizeHost(s, origin string) string {
+	if origin == "" {
+		return s
+	}
+	s = strings.ReplaceAll(s, origin, "https://"+RecordedHost)
+	// JSON encoders may escape the slashes of the links.
+	return strings.ReplaceAll(s, strings.ReplaceAll(origin, "/", `\/`), `https:\/\/`+RecordedHost)
+}
+
+func isMultipart(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && strings.HasPrefix(mediaType, "multipart/")
+}
@@ -0,0 +1,100 @@
+package akeneoxtest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderSanitizesAndReplays(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.yaml")
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("unable to create recorder: %v", err)
+	}
+	token, recorded := exchange(t, recorder.Client(), server.URL, "")
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("unable to save fixture: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read fixture: %v", err)
+	}
+	fixture := string(b)
+	for _, secret := range []string{token, Password, "Basic ", "Bearer ", server.Listener.Addr().String()} {
+		if strings.Contains(fixture, secret) {
+			t.Errorf("fixture contains %q:\n%s", secret, fixture)
+		}
+	}
+
+	replayer, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("unable to create replayer: %v", err)
+	}
+	_, replayed := exchange(t, replayer.Client(), "http://localhost:1", Redacted)
+	if replayed != strings.ReplaceAll(recorded, server.URL, "http://localhost:1") {
+		t.Errorf("expected replayed response %s, got %s", recorded, replayed)
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("expected all interactions to be replayed, %d left", len(unused))
+	}
+
+	// Every interaction is replayed once.
+	if _, err := replayer.Client().Get("http://localhost:1/api/rest/v1/channels/ecommerce"); err == nil {
+		t.Errorf("expected an error for a request which was not recorded")
+	}
+}
+
+// exchange authenticates and fetches a channel, it returns the access token
+// and the channel response body.
+func exchange(t *testing.T, client *http.Client, baseURL string, expectedToken string) (string, string) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, baseURL+tokenPath, strings.NewReader(`{"grant_type":"password","username":"`+Username+`","password":"`+Password+`"}`))
+	req.SetBasicAuth(ClientID, Secret)
+	req.Header.Set("Content-Type", "application/json")
+	body := roundTrip(t, client, req, http.StatusOK)
+
+	token := between(body, `"access_token":"`, `"`)
+	if expectedToken != "" && token != expectedToken {
+		t.Errorf("expected access token %q, got %q", expectedToken, token)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, baseURL+restPrefix+"/channels/ecommerce", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return token, roundTrip(t, client, req, http.StatusOK)
+}
+
+func roundTrip(t *testing.T, client *http.Client, req *http.Request, expectedStatus int) string {
+	t.Helper()
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.StatusCode != expectedStatus {
+		t.Fatalf("expected status %d, got %d: %s", expectedStatus, res.StatusCode, b)
+	}
+	return string(bytes.TrimSpace(b))
+}
+
+func between(s, start, end string) string {
+	_, after, _ := strings.Cut(s, start)
+	value, _, _ := strings.Cut(after, end)
+	return value
+}
@@ -0,0 +1,445 @@
+package akeneoxtest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// collection holds objects by code in the order they were created.
+type collection struct {
+	items map[string]map[string]any
+	codes []string
+}
+
+func newCollection() *collection {
+	return &collection{items: make(map[string]map[string]any)}
+}
+
+func (c *collection) get(code string) (map[string]any, bool) {
+	item, ok := c.items[code]
+	if !ok {
+		return nil, false
+	}
+	return deepCopy(item), true
+}
+
+func (c *collection) put(code string, item map[string]any) {
+	if _, ok := c.items[code]; !ok {
+		c.codes = append(c.codes, code)
+	}
+	c.items[code] = deepCopy(item)
+}
+
+func (c *collection) delete(code string) {
+	delete(c.items, code)
+	c.codes = slices.DeleteFunc(c.codes, func(v string) bool { return v == code })
+}
+
+func (c *collection) list() []map[string]any {
+	items := make([]map[string]any, len(c.codes))
+	for i, code := range c.codes {
+		items[i] = deepCopy(c.items[code])
+	}
+	return items
+}
+
+// resource describes the endpoints of an object type. The handlers implement
+// the behavior shared by all objects, the hooks the rules of the object type.
+type resource struct {
+	s *Server
+	// name is the object type used in messages, e.g. "Attribute option".
+	name string
+	// properties are the properties of the object, others are rejected.
+	properties []string
+	// readOnly are properties which are accepted but ignored on write.
+	readOnly []string
+	// immutable are properties which can not be changed once set.
+	immutable []string
+	// searchable are the properties of the search filter.
+	searchable []string
+
+	// collection returns the objects of the request, it fails when the parent
+	// object of the request does not exist.
+	collection func(r *http.Request) (*collection, error)
+	// empty returns an object with the defaults of all properties.
+	empty func(r *http.Request) map[string]any
+	// validate checks the object before it is saved, prior is nil on create.
+	validate func(r *http.Request, item, prior map[string]any) []violation
+	// saved is called after the object was saved.
+	saved func(r *http.Request, item, prior map[string]any)
+	// normalize returns the object as it is returned by the API.
+	normalize func(r *http.Request, item map[string]any) map[string]any
+}
+
+func (res *resource) list(w http.ResponseWriter, r *http.Request) error {
+	c, err := res.collection(r)
+	if err != nil {
+		return err
+	}
+
+	search, err := parseSearch(r, res.searchable)
+	if err != nil {
+		return err
+	}
+
+	items := make([]map[string]any, 0)
+	for _, item := range c.list() {
+		item = res.output(r, item)
+		if search.match(item) {
+			items = append(items, item)
+		}
+	}
+
+	return res.s.writeList(w, r, items)
+}
+
+func (res *resource) get(w http.ResponseWriter, r *http.Request) error {
+	c, err := res.collection(r)
+	if err != nil {
+		return err
+	}
+
+	code := r.PathValue("code")
+	item, ok := c.get(code)
+	if !ok {
+		return notFound("%s \"%s\" does not exist.", res.name, code)
+	}
+
+	writeJSON(w, http.StatusOK, res.output(r, item))
+	return nil
+}
+
+func (res *resource) create(w http.ResponseWriter, r *http.Request) error {
+	c, err := res.collection(r)
+	if err != nil {
+		return err
+	}
+
+	body, err := decodeObject(r)
+	if err != nil {
+		return err
+	}
+
+	code, _ := body["code"].(string)
+	if code == "" {
+		return validationFailed([]violation{{Property: "code", Message: "This value should not be blank."}})
+	}
+	if _, ok := c.get(code); ok {
+		return validationFailed([]violation{{Property: "code", Message: "This value is already used."}})
+	}
+
+	if err := res.save(r, c, code, body, nil); err != nil {
+		return err
+	}
+
+	res.s.writeSaved(w, true, r.URL.Path+"/"+code)
+	return nil
+}
+
+func (res *resource) update(w http.ResponseWriter, r *http.Request) error {
+	c, err := res.collection(r)
+	if err != nil {
+		return err
+	}
+
+	body, err := decodeObject(r)
+	if err != nil {
+		return err
+	}
+
+	code := r.PathValue("code")
+	if v, ok := body["code"]; ok && v != code {
+		return unprocessable("The code \"%v\" provided in the request body must match the code \"%s\" provided in the url.", v, code)
+	}
+
+	prior, exists := c.get(code)
+	if err := res.save(r, c, code, body, prior); err != nil {
+		return err
+	}
+
+	res.s.writeSaved(w, !exists, r.URL.Path)
+	return nil
+}
+
+func (res *resource) delete(w http.ResponseWriter, r *http.Request) error {
+	c, err := res.collection(r)
+	if err != nil {
+		return err
+	}
+
+	code := r.PathValue("code")
+	if _, ok := c.get(code); !ok {
+		return notFound("%s \"%s\" does not exist.", res.name, code)
+	}
+	c.delete(code)
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// updateCollection creates or updates the objects of a collection patch
+// request, one JSON object per line, and answers with the status of each line.
+func (res *resource) updateCollection(w http.ResponseWriter, r *http.Request) error {
+	c, err := res.collection(r)
+	if err != nil {
+		return err
+	}
+
+	if r.Header.Get("Content-Type") != collectionContentType {
+		return &apiError{
+			status:  http.StatusUnsupportedMediaType,
+			message: fmt.Sprintf("\"%s\" in \"Content-Type\" header is not valid. Only \"%s\" is allowed.", r.Header.Get("Content-Type"), collectionContentType),
+		}
+	}
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, bytes.Clone(line))
+		}
+	}
+	if len(lines) > maxPageSize {
+		return &apiError{
+			status:  http.StatusRequestEntityTooLarge,
+			message: fmt.Sprintf("Too many resources to process, %d is the maximum allowed.", maxPageSize),
+		}
+	}
+
+	w.Header().Set("Content-Type", collectionContentType)
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	for i, line := range lines {
+		status := map[string]any{"line": i + 1}
+
+		body, err := decodeJSON(line)
+		code, _ := body["code"].(string)
+		if err == nil && code == "" {
+			err = validationFailed([]violation{{Property: "code", Message: "This value should not be blank."}})
+		}
+		if code != "" {
+			status["code"] = code
+		}
+
+		if err == nil {
+			prior, exists := c.get(code)
+			err = res.save(r, c, code, body, prior)
+			status["status_code"] = http.StatusNoContent
+			if !exists {
+				status["status_code"] = http.StatusCreated
+			}
+		}
+
+		if apiErr, ok := err.(*apiError); ok {
+			status["status_code"] = apiErr.status
+			status["message"] = apiErr.message
+			if len(apiErr.errors) > 0 {
+				status["errors"] = apiErr.errors
+			}
+		}
+
+		_ = encoder.Encode(status)
+	}
+
+	return nil
+}
+
+// save merges the body into the prior object, or the defaults on create, and
+// stores the result when it is valid.
+func (res *resource) save(r *http.Request, c *collection, code string, body, prior map[string]any) error {
+	for property := range body {
+		if !slices.Contains(res.properties, property) && !slices.Contains(res.readOnly, property) && property != "_links" {
+			return unprocessable("Property \"%s\" does not exist. Check the expected format on the API documentation.", property)
+		}
+	}
+	for _, property := range res.readOnly {
+		delete(body, property)
+	}
+
+	item := prior
+	if item == nil {
+		item = res.empty(r)
+	} else {
+		item = deepCopy(item)
+	}
+	merge(item, body)
+	item["code"] = code
+
+	// Akeneo removes the label of a locale for an empty label.
+	if labels, ok := item["labels"].(map[string]any); ok {
+		for locale, label := range labels {
+			if label == "" {
+				delete(labels, locale)
+			}
+		}
+	}
+
+	var violations []violation
+	if prior != nil {
+		for _, property := range res.immutable {
+			if prior[property] != nil && !equal(prior[property], item[property]) {
+				violations = append(violations, violation{Property: property, Message: "This property cannot be changed."})
+			}
+		}
+	}
+	if res.validate != nil {
+		violations = append(violations, res.validate(r, item, prior)...)
+	}
+	if len(violations) > 0 {
+		return validationFailed(violations)
+	}
+
+	c.put(code, item)
+	if res.saved != nil {
+		res.saved(r, item, prior)
+	}
+	return nil
+}
+
+func (res *resource) output(r *http.Request, item map[string]any) map[string]any {
+	if res.normalize != nil {
+		return res.normalize(r, item)
+	}
+	return item
+}
+
+func (res *resource) route(mux *http.ServeMux, path string, create bool, updateCollection bool) {
+	res.s.handle(mux, "GET "+path, res.list)
+	res.s.handle(mux, "GET "+path+"/{code}", res.get)
+	res.s.handle(mux, "PATCH "+path+"/{code}", res.update)
+	if create {
+		res.s.handle(mux, "POST "+path, res.create)
+	}
+	if updateCollection {
+		res.s.handle(mux, "PATCH "+path, res.updateCollection)
+	}
+}
+
+// merge merges src into dst the way Akeneo applies PATCH requests: objects are
+// merged, other values replace the prior value, and null removes a key of a
+// nested object such as a label.
+func merge(dst, src map[string]any) {
+	for k, v := range src {
+		if vm, ok := v.(map[string]any); ok {
+			if dm, ok := dst[k].(map[string]any); ok {
+				mergeNested(dm, vm)
+				continue
+			}
+			nested := make(map[string]any, len(vm))
+			mergeNested(nested, vm)
+			dst[k] = nested
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func mergeNested(dst, src map[string]any) {
+	for k, v := range src {
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+		if vm, ok := v.(map[string]any); ok {
+			if dm, ok := dst[k].(map[string]any); ok {
+				mergeNested(dm, vm)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// deepCopy copies an object through its JSON representation.
+func deepCopy(item map[string]any) map[string]any {
+	b, _ := json.Marshal(item)
+	var result map[string]any
+	_ = json.Unmarshal(b, &result)
+	return result
+}
+
+func equal(a, b any) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return bytes.Equal(ja, jb)
+}
+
+// search is the search filter of a list request, e.g.
+// {"type":[{"operator":"IN","value":["pim_catalog_text"]}]}.
+type search map[string][]struct {
+	Operator string `json:"operator"`
+	Value    any    `json:"value"`
+}
+
+func parseSearch(r *http.Request, searchable []string) (search, error) {
+	v := r.URL.Query().Get("search")
+	if v == "" {
+		return nil, nil
+	}
+
+	var filter search
+	if err := json.Unmarshal([]byte(v), &filter); err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Search query parameter should be valid JSON."}
+	}
+
+	for property, conditions := range filter {
+		for _, condition := range conditions {
+			if !slices.Contains(searchable, property) || !slices.Contains([]string{"=", "IN"}, condition.Operator) {
+				return nil, unprocessable("Filter on property \"%s\" is not supported or does not support operator \"%s\".", property, condition.Operator)
+			}
+		}
+	}
+	return filter, nil
+}
+
+func (f search) match(item map[string]any) bool {
+	for property, conditions := range f {
+		for _, condition := range conditions {
+			switch condition.Operator {
+			case "=":
+				if !equal(item[property], condition.Value) {
+					return false
+				}
+			case "IN":
+				values, _ := condition.Value.([]any)
+				if !slices.ContainsFunc(values, func(v any) bool { return equal(item[property], v) }) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// stringList returns the strings of a JSON list value.
+func stringList(v any) []string {
+	values, _ := v.([]any)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// anyList returns the strings as a JSON list value.
+func anyList(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
+// isEmpty reports whether a JSON value is null or an empty string.
+func isEmpty(v any) bool {
+	s, ok := v.(string)
+	return v == nil || ok && strings.TrimSpace(s) == ""
+}
@@ -0,0 +1,423 @@
+// Package akeneoxtest provides a fake Akeneo PIM for tests.
+//
+// The server implements the REST API endpoints used by the provider with an
+// in-memory catalog. Requests are validated the way Akeneo does for the cases
+// the provider depends on: unknown codes are answered with 404, invalid objects
+// with 422 and the violated properties, and PATCH requests merge the body into
+// the stored object. Tests can therefore run without a real PIM.
+package akeneoxtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials accepted by the server.
+const (
+	ClientID = "client_id"
+	Secret   = "secret"
+	Username = "admin"
+	Password = "admin"
+
+	// AccessToken is the long-lived token of a connected app, it never expires.
+	AccessToken = "app_token"
+)
+
+// IdentifierAttribute is the identifier attribute of the seeded catalog.
+const IdentifierAttribute = "sku"
+
+const (
+	tokenPath    = "/api/oauth/v1/token"
+	restPrefix   = "/api/rest/v1"
+	tokenExpires = time.Hour
+
+	defaultPageSize = 10
+	maxPageSize     = 100
+
+	collectionContentType = "application/vnd.akeneo.collection+json"
+)
+
+// Server is a fake Akeneo PIM listening on a local address.
+type Server struct {
+	*httptest.Server
+
+	// TokenLifetime is the lifetime of the access tokens granted by the server.
+	TokenLifetime time.Duration
+
+	mu            sync.Mutex
+	tokens        map[string]time.Time
+	refreshTokens map[string]bool
+	grants        int
+
+	locales             []string
+	currencies          []string
+	attributes          *collection
+	attributeGroups     *collection
+	attributeOptions    map[string]*collection
+	families            *collection
+	familyVariants      map[string]*collection
+	channels            *collection
+	categories          *collection
+	categoryTemplates   *collection
+	associationTypes    *collection
+	measurementFamilies *collection
+	productModels       *collection
+	mediaFiles          *collection
+	categoryMediaFiles  *collection
+}
+
+// NewServer starts a fake Akeneo PIM with a minimal catalog: the attribute
+// group "other", the identifier attribute "sku", the category tree "master"
+// and the channel "ecommerce". The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		TokenLifetime:       tokenExpires,
+		tokens:              make(map[string]time.Time),
+		refreshTokens:       make(map[string]bool),
+		locales:             defaultLocales,
+		currencies:          defaultCurrencies,
+		attributes:          newCollection(),
+		attributeGroups:     newCollection(),
+		attributeOptions:    make(map[string]*collection),
+		families:            newCollection(),
+		familyVariants:      make(map[string]*collection),
+		channels:            newCollection(),
+		categories:          newCollection(),
+		categoryTemplates:   newCollection(),
+		associationTypes:    newCollection(),
+		measurementFamilies: newCollection(),
+		productModels:       newCollection(),
+		mediaFiles:          newCollection(),
+		categoryMediaFiles:  newCollection(),
+	}
+	s.tokens[AccessToken] = time.Now().AddDate(100, 0, 0)
+	s.seed()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+tokenPath, s.handleToken)
+	s.routeAttributes(mux)
+	s.routeFamilies(mux)
+	s.routeChannels(mux)
+	s.routeCategories(mux)
+	s.routeAssociationTypes(mux)
+	s.routeMeasurementFamilies(mux)
+	s.routeProducts(mux)
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Host returns the host and port of the server, as configured in the provider.
+func (s *Server) Host() string {
+	u, _ := url.Parse(s.URL)
+	return u.Host
+}
+
+// Grants returns the number of tokens granted by the server.
+func (s *Server) Grants() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grants
+}
+
+// RevokeTokens invalidates the granted access and refresh tokens before they
+// expire, as Akeneo does when the password of the user changes.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for token := range s.tokens {
+		if token != AccessToken {
+			delete(s.tokens, token)
+		}
+	}
+	clear(s.refreshTokens)
+}
+
+func (s *Server) seed() {
+	s.attributeGroups.put("other", map[string]any{
+		"code":       "other",
+		"sort_order": 0,
+		"attributes": []any{IdentifierAttribute},
+		"labels":     map[string]any{"en_US": "Other"},
+	})
+
+	identifier := emptyAttribute()
+	merge(identifier, map[string]any{
+		"code":                   IdentifierAttribute,
+		"type":                   "pim_catalog_identifier",
+		"group":                  "other",
+		"unique":                 true,
+		"useable_as_grid_filter": true,
+		"labels":                 map[string]any{"en_US": "SKU"},
+	})
+	s.attributes.put(IdentifierAttribute, identifier)
+
+	s.categories.put("master", map[string]any{
+		"code":    "master",
+		"parent":  nil,
+		"updated": now(),
+		"labels":  map[string]any{"en_US": "Master catalog"},
+		"values":  map[string]any{},
+	})
+
+	s.channels.put("ecommerce", map[string]any{
+		"code":             "ecommerce",
+		"currencies":       []any{"USD"},
+		"locales":          []any{"en_US"},
+		"category_tree":    "master",
+		"conversion_units": map[string]any{},
+		"labels":           map[string]any{"en_US": "Ecommerce"},
+	})
+
+	for _, family := range defaultMeasurementFamilies {
+		s.measurementFamilies.put(family["code"].(string), family)
+	}
+}
+
+// handler handles an authenticated REST API request. The catalog is locked
+// for the duration of the request.
+type handler func(w http.ResponseWriter, r *http.Request) error
+
+func (s *Server) handle(mux *http.ServeMux, pattern string, h handler) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.authorized(r) {
+			writeError(w, &apiError{status: http.StatusUnauthorized, message: "The access token provided is invalid."})
+			return
+		}
+
+		if err := h(w, r); err != nil {
+			writeError(w, err)
+		}
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	exp, ok := s.tokens[token]
+	return ok && time.Now().Before(exp)
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credentials, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Basic ")
+	decoded, err := base64.StdEncoding.DecodeString(credentials)
+	if !ok || err != nil || string(decoded) != ClientID+":"+Secret {
+		writeError(w, &apiError{status: http.StatusUnprocessableEntity, message: "Parameter \"client_id\" is missing or does not match any client, or secret is invalid"})
+		return
+	}
+
+	var request map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, errInvalidJSON)
+		return
+	}
+
+	switch request["grant_type"] {
+	case "password":
+		if request["username"] != Username || request["password"] != Password {
+			writeError(w, &apiError{status: http.StatusUnprocessableEntity, message: "No user found for the given username and password"})
+			return
+		}
+	case "refresh_token":
+		if !s.refreshTokens[request["refresh_token"]] {
+			writeError(w, &apiError{status: http.StatusUnprocessableEntity, message: "Refresh token is invalid or has expired"})
+			return
+		}
+		delete(s.refreshTokens, request["refresh_token"])
+	default:
+		writeError(w, &apiError{status: http.StatusUnprocessableEntity, message: "Parameter \"grant_type\" is missing, or does not match any allowed grant types"})
+		return
+	}
+
+	s.grants++
+	accessToken := fmt.Sprintf("access-%d", s.grants)
+	refreshToken := fmt.Sprintf("refresh-%d", s.grants)
+	s.tokens[accessToken] = time.Now().Add(s.TokenLifetime)
+	s.refreshTokens[refreshToken] = true
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token":  accessToken,
+		"expires_in":    int(s.TokenLifetime.Seconds()),
+		"token_type":    "bearer",
+		"scope":         nil,
+		"refresh_token": refreshToken,
+	})
+}
+
+// apiError is an error response of the API.
+type apiError struct {
+	status  int
+	message string
+	errors  []violation
+}
+
+// violation is a validation error of a single property.
+type violation struct {
+	Property  string `json:"property"`
+	Message   string `json:"message"`
+	Attribute string `json:"attribute,omitempty"`
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+var errInvalidJSON = &apiError{status: http.StatusBadRequest, message: "Invalid json message received"}
+
+func notFound(format string, args ...any) *apiError {
+	return &apiError{status: http.StatusNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func unprocessable(format string, args ...any) *apiError {
+	return &apiError{status: http.StatusUnprocessableEntity, message: fmt.Sprintf(format, args...)}
+}
+
+func validationFailed(violations []violation) *apiError {
+	return &apiError{
+		status:  http.StatusUnprocessableEntity,
+		message: "Validation failed.",
+		errors:  violations,
+	}
+}
+
+func (e *apiError) body() map[string]any {
+	body := map[string]any{
+		"code":    e.status,
+		"message": e.message,
+	}
+	if len(e.errors) > 0 {
+		body["errors"] = e.errors
+	}
+	return body
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		apiErr = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+	}
+	writeJSON(w, apiErr.status, apiErr.body())
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeSaved answers a create or update of the object at the given path.
+func (s *Server) writeSaved(w http.ResponseWriter, created bool, path string) {
+	w.Header().Set("Location", s.URL+path)
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodeObject decodes the JSON object of the request body.
+func decodeObject(r *http.Request) (map[string]any, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errInvalidJSON
+	}
+	return decodeJSON(body)
+}
+
+func decodeJSON(body []byte) (map[string]any, error) {
+	var object map[string]any
+	if err := json.Unmarshal(body, &object); err != nil || object == nil {
+		return nil, errInvalidJSON
+	}
+	return object, nil
+}
+
+// writeList writes a page of the items as a paginated list response.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, items []map[string]any) error {
+	query := r.URL.Query()
+
+	limit := defaultPageSize
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return unprocessable("\"%s\" is not a valid limit number.", v)
+		}
+		if n > maxPageSize {
+			return unprocessable("You cannot request more than %d items.", maxPageSize)
+		}
+		limit = n
+	}
+
+	page := 1
+	if v := query.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return unprocessable("\"%s\" is not a valid page number.", v)
+		}
+		page = n
+	}
+
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+
+	pageItems := make([]map[string]any, 0, end-start)
+	for _, item := range items[start:end] {
+		item["_links"] = map[string]any{
+			"self": map[string]any{"href": s.URL + r.URL.Path + "/" + url.PathEscape(item["code"].(string))},
+		}
+		pageItems = append(pageItems, item)
+	}
+
+	link := func(page int) map[string]any {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(page))
+		q.Set("limit", strconv.Itoa(limit))
+		return map[string]any{"href": s.URL + r.URL.Path + "?" + q.Encode()}
+	}
+
+	links := map[string]any{
+		"self":  link(page),
+		"first": link(1),
+	}
+	if page > 1 {
+		links["previous"] = link(page - 1)
+	}
+	if end < len(items) {
+		links["next"] = link(page + 1)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"_links":       links,
+		"current_page": page,
+		"_embedded": map[string]any{
+			"items": pageItems,
+		},
+	})
+	return nil
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
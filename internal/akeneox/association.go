@@ -2,25 +2,36 @@ package akeneox
 
 import (
 	"fmt"
-
-	goakeneo "github.com/ezifyio/go-akeneo"
 )
 
 const (
+	associationTypesPath       = "/api/rest/v1/association-types"
 	associationTypesSinglePath = "/api/rest/v1/association-types/%s"
 )
 
 type AssociationTypeService struct {
-	client *goakeneo.Client
+	client *Client
 }
 
-func NewAssociationTypeClient(client *goakeneo.Client) *AssociationTypeService {
+func NewAssociationTypeClient(client *Client) *AssociationTypeService {
 	return &AssociationTypeService{
 		client: client,
 	}
 }
 
-func (a *AssociationTypeService) UpdateAssociationTypes(association AssociationType) error {
+// CreateAssociationType creates a new association type, it fails when the association type already exists.
+func (a *AssociationTypeService) CreateAssociationType(association AssociationType) error {
+	return a.client.POST(
+		associationTypesPath,
+		nil,
+		association,
+		nil,
+	)
+}
+
+// UpdateAssociationType updates an existing association type. Akeneo rejects
+// changes of is_quantified and is_two_way once the association type is created.
+func (a *AssociationTypeService) UpdateAssociationType(association AssociationType) error {
 	err := a.client.PATCH(
 		fmt.Sprintf(associationTypesSinglePath, association.Code),
 		nil,
@@ -46,3 +57,7 @@ func (a *AssociationTypeService) GetAssociationType(code string) (*AssociationTy
 	}
 	return response, nil
 }
+
+func (a *AssociationTypeService) ListAssociationTypes() ([]AssociationType, error) {
+	return listAll[AssociationType](a.client, associationTypesPath, nil)
+}
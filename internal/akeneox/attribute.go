@@ -2,6 +2,8 @@ package akeneox
 
 import (
 	"fmt"
+	"net/url"
+
 	goakeneo "github.com/ezifyio/go-akeneo"
 )
 
@@ -15,15 +17,59 @@ const (
 )
 
 type AttributeService struct {
-	goakeneo.AttributeService
-	client *goakeneo.Client
+	client *Client
 }
 
-func NewAttributeClient(client *goakeneo.Client) *AttributeService {
+func NewAttributeClient(client *Client) *AttributeService {
 	return &AttributeService{
-		AttributeService: client.Attribute,
-		client:           client,
+		client: client,
+	}
+}
+
+func (a *AttributeService) GetAttribute(code string) (*goakeneo.Attribute, error) {
+	response := new(goakeneo.Attribute)
+	err := a.client.GET(
+		fmt.Sprintf(attributeSinglePath, code),
+		nil,
+		nil,
+		response,
+	)
+	if err != nil {
+		return nil, err
 	}
+	return response, nil
+}
+
+func (a *AttributeService) ListAttributes() ([]goakeneo.Attribute, error) {
+	return listAll[goakeneo.Attribute](a.client, attributePath, nil)
+}
+
+// ListAttributesOfType returns the attributes of the given types.
+func (a *AttributeService) ListAttributesOfType(attributeTypes ...string) ([]goakeneo.Attribute, error) {
+	search := make(goakeneo.SearchFilter)
+	search.Add("type", "IN", attributeTypes)
+
+	return listAll[goakeneo.Attribute](a.client, attributePath, url.Values{
+		"search": []string{search.String()},
+	})
+}
+
+// ListMetricAttributes returns the metric attributes using the given measurement
+// family. The API can only search attributes by type, so the measurement family
+// is filtered client-side.
+func (a *AttributeService) ListMetricAttributes(measurementFamily string) ([]goakeneo.Attribute, error) {
+	attributes, err := a.ListAttributesOfType("pim_catalog_metric")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []goakeneo.Attribute
+	for _, attribute := range attributes {
+		if attribute.MetricFamily != nil && *attribute.MetricFamily == measurementFamily {
+			result = append(result, attribute)
+		}
+	}
+	return result, nil
 }
 
 func (a *AttributeService) CreateAttribute(attribute goakeneo.Attribute) error {
@@ -86,6 +132,21 @@ func (a *AttributeService) GetAttributeOption(attribute string, code string) (*g
 	return response, nil
 }
 
+// ListAttributeOptions returns all options of the attribute.
+func (a *AttributeService) ListAttributeOptions(attribute string) ([]goakeneo.AttributeOption, error) {
+	return listAll[goakeneo.AttributeOption](a.client, fmt.Sprintf(attributeOptionPath, attribute), nil)
+}
+
+// UpdateAttributeOptions creates or updates the options of the attribute with collection patch requests.
+func (a *AttributeService) UpdateAttributeOptions(attribute string, options []goakeneo.AttributeOption) ([]CollectionResponseLine, error) {
+	items := make([]any, len(options))
+	for i, v := range options {
+		items[i] = v
+	}
+
+	return a.client.PatchCollection(fmt.Sprintf(attributeOptionPath, attribute), items)
+}
+
 func (a *AttributeService) GetAttributeGroup(code string) (*AttributeGroup, error) {
 	response := new(AttributeGroup)
 	err := a.client.GET(
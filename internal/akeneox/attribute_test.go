@@ -0,0 +1,86 @@
+package akeneox
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox/akeneoxtest"
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+func newFakeClient(t *testing.T) *Client {
+	t.Helper()
+
+	server := akeneoxtest.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(goakeneo.Connector{
+		ClientID: akeneoxtest.ClientID,
+		Secret:   akeneoxtest.Secret,
+		UserName: akeneoxtest.Username,
+		Password: akeneoxtest.Password,
+	}, server.URL)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	return client
+}
+
+func TestListAttributesPaginates(t *testing.T) {
+	service := NewAttributeClient(newFakeClient(t))
+
+	// The identifier attribute is part of the fake catalog, so the attributes
+	// span two pages.
+	for i := 0; i < defaultPageSize; i++ {
+		if err := service.CreateAttribute(goakeneo.Attribute{
+			Code:  fmt.Sprintf("text_%d", i),
+			Type:  "pim_catalog_text",
+			Group: "other",
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	attributes, err := service.ListAttributes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(attributes) != defaultPageSize+1 {
+		t.Errorf("expected %d attributes, got %d", defaultPageSize+1, len(attributes))
+	}
+
+	texts, err := service.ListAttributesOfType("pim_catalog_text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(texts) != defaultPageSize {
+		t.Errorf("expected %d text attributes, got %d", defaultPageSize, len(texts))
+	}
+}
+
+func TestAttributeErrors(t *testing.T) {
+	service := NewAttributeClient(newFakeClient(t))
+
+	if _, err := service.GetAttribute("missing"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	err := service.CreateAttribute(goakeneo.Attribute{
+		Code:  "weight",
+		Type:  "pim_catalog_metric",
+		Group: "other",
+	})
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected API error, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, apiErr.StatusCode)
+	}
+	if len(apiErr.Errors) == 0 {
+		t.Errorf("expected validation errors, got none")
+	}
+}
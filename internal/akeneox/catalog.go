@@ -0,0 +1,73 @@
+package akeneox
+
+import (
+	"sync"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+// CatalogSnapshot lists the channels, attributes and families of the catalog
+// once per provider run. Plan time checks of several resources read the same
+// lists instead of each downloading the whole catalog.
+//
+// The lists are not updated by changes made through the API afterwards, so
+// they must only be used for checks which tolerate a stale catalog.
+type CatalogSnapshot struct {
+	channels   *ChannelService
+	attributes *AttributeService
+	families   *FamilyService
+
+	channelList   snapshotList[goakeneo.Channel]
+	attributeList snapshotList[goakeneo.Attribute]
+	familyList    snapshotList[goakeneo.Family]
+}
+
+// NewCatalogSnapshot creates a snapshot which lists the catalog on first use.
+func NewCatalogSnapshot(client *Client) *CatalogSnapshot {
+	return &CatalogSnapshot{
+		channels:   NewChannelClient(client),
+		attributes: NewAttributeClient(client),
+		families:   NewFamilyClient(client),
+	}
+}
+
+// Channels returns all channels of the catalog.
+func (s *CatalogSnapshot) Channels() ([]goakeneo.Channel, error) {
+	return s.channelList.get(s.channels.ListChannels)
+}
+
+// Attributes returns all attributes of the catalog.
+func (s *CatalogSnapshot) Attributes() ([]goakeneo.Attribute, error) {
+	return s.attributeList.get(s.attributes.ListAttributes)
+}
+
+// Families returns all families of the catalog.
+func (s *CatalogSnapshot) Families() ([]goakeneo.Family, error) {
+	return s.familyList.get(s.families.ListFamilies)
+}
+
+// snapshotList keeps the first successfully listed items. Failed listings
+// are not kept, so they are retried on the next use.
+type snapshotList[T any] struct {
+	mu     sync.Mutex
+	items  []T
+	loaded bool
+}
+
+func (l *snapshotList[T]) get(list func() ([]T, error)) ([]T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.loaded {
+		return l.items, nil
+	}
+
+	items, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	l.items = items
+	l.loaded = true
+	return items, nil
+}
@@ -0,0 +1,77 @@
+package akeneox
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+func TestCatalogSnapshotListsOnce(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	failFamilies := true
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests[r.URL.Path]++
+
+		var items any
+		switch r.URL.Path {
+		case channelPath:
+			items = []goakeneo.Channel{{Code: "ecommerce", Locales: []string{"en_US"}}}
+		case attributePath:
+			items = []goakeneo.Attribute{{Code: "name"}}
+		case familyPath:
+			if failFamilies {
+				failFamilies = false
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			items = []goakeneo.Family{{Code: "shoes"}}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"_links":    map[string]any{},
+			"_embedded": map[string]any{"items": items},
+		})
+	})
+
+	snapshot := NewCatalogSnapshot(client)
+	for i := 0; i < 2; i++ {
+		channels, err := snapshot.Channels()
+		if err != nil || len(channels) != 1 || channels[0].Code != "ecommerce" {
+			t.Fatalf("unexpected channels %v, error %v", channels, err)
+		}
+		attributes, err := snapshot.Attributes()
+		if err != nil || len(attributes) != 1 || attributes[0].Code != "name" {
+			t.Fatalf("unexpected attributes %v, error %v", attributes, err)
+		}
+	}
+
+	// A failed listing is not kept.
+	if _, err := snapshot.Families(); err == nil {
+		t.Fatal("expected an error")
+	}
+	for i := 0; i < 2; i++ {
+		families, err := snapshot.Families()
+		if err != nil || len(families) != 1 || families[0].Code != "shoes" {
+			t.Fatalf("unexpected families %v, error %v", families, err)
+		}
+	}
+
+	expected := map[string]int{channelPath: 1, attributePath: 1, familyPath: 2}
+	for path, count := range expected {
+		if requests[path] != count {
+			t.Errorf("expected %d requests to %s, got %d", count, path, requests[path])
+		}
+	}
+}
@@ -2,28 +2,36 @@ package akeneox
 
 import (
 	"fmt"
-
-	goakeneo "github.com/ezifyio/go-akeneo"
+	"net/url"
 )
 
 const (
-	categoryPath       = "/api/rest/v1/categories"
-	categorySinglePath = "/api/rest/v1/categories/%s"
+	categoryPath               = "/api/rest/v1/categories"
+	categorySinglePath         = "/api/rest/v1/categories/%s"
+	categoryTemplatePath       = "/api/rest/v1/category-templates"
+	categoryTemplateSinglePath = "/api/rest/v1/category-templates/%s"
+	categoryMediaFilePath      = "/api/rest/v1/category-media-files"
 )
 
 type CategoryService struct {
-	goakeneo.CategoryService
-	client *goakeneo.Client
+	client *Client
 }
 
-func NewCategoryClient(client *goakeneo.Client) *CategoryService {
+func NewCategoryClient(client *Client) *CategoryService {
 	return &CategoryService{
-		CategoryService: client.Category,
-		client:          client,
+		client: client,
+	}
+}
+
+// categoryQuery requests the position and enriched values, which are omitted by default.
+func categoryQuery() url.Values {
+	return url.Values{
+		"with_position":            []string{"true"},
+		"with_enriched_attributes": []string{"true"},
 	}
 }
 
-func (a *CategoryService) CreateCategory(category goakeneo.Category) error {
+func (a *CategoryService) CreateCategory(category Category) error {
 	return a.client.POST(
 		categoryPath,
 		nil,
@@ -32,8 +40,8 @@ func (a *CategoryService) CreateCategory(category goakeneo.Category) error {
 	)
 }
 
-func (a *CategoryService) UpdateCategory(category goakeneo.Category) (*goakeneo.Category, error) {
-	response := new(goakeneo.Category)
+func (a *CategoryService) UpdateCategory(category Category) (*Category, error) {
+	response := new(Category)
 	err := a.client.PATCH(
 		fmt.Sprintf(categorySinglePath, category.Code),
 		nil,
@@ -46,6 +54,69 @@ func (a *CategoryService) UpdateCategory(category goakeneo.Category) (*goakeneo.
 	return response, nil
 }
 
-func (a *CategoryService) GetCategory(code string) (*goakeneo.Category, error) {
-	return a.client.Category.Get(code)
+func (a *CategoryService) GetCategory(code string) (*Category, error) {
+	response := new(Category)
+	err := a.client.GET(
+		fmt.Sprintf(categorySinglePath, code),
+		categoryQuery(),
+		nil,
+		response,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListCategories returns all categories of the catalog with their position.
+func (a *CategoryService) ListCategories() ([]Category, error) {
+	query := url.Values{
+		"with_position": []string{"true"},
+	}
+
+	return listAll[Category](a.client, categoryPath, query)
+}
+
+func (a *CategoryService) CreateCategoryTemplate(template CategoryTemplate) error {
+	return a.client.POST(
+		categoryTemplatePath,
+		nil,
+		template,
+		nil,
+	)
+}
+
+func (a *CategoryService) UpdateCategoryTemplate(template CategoryTemplate) error {
+	return a.client.PATCH(
+		fmt.Sprintf(categoryTemplateSinglePath, template.Code),
+		nil,
+		template,
+		nil,
+	)
+}
+
+func (a *CategoryService) GetCategoryTemplate(code string) (*CategoryTemplate, error) {
+	response := new(CategoryTemplate)
+	err := a.client.GET(
+		fmt.Sprintf(categoryTemplateSinglePath, code),
+		nil,
+		nil,
+		response,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (a *CategoryService) DeleteCategoryTemplate(code string) error {
+	return a.client.DELETE(
+		fmt.Sprintf(categoryTemplateSinglePath, code),
+		nil,
+	)
+}
+
+// UploadCategoryMediaFile uploads a local file for a category image value and returns its media code.
+func (a *CategoryService) UploadCategoryMediaFile(filePath string) (string, error) {
+	return uploadMediaFile(a.client, categoryMediaFilePath, nil, filePath)
 }
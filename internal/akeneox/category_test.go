@@ -0,0 +1,101 @@
+package akeneox
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(authTokenPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(authResponse{
+			AccessToken: "token",
+			ExpiresIn:   3600,
+		})
+	})
+	mux.HandleFunc("/", handler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(goakeneo.Connector{
+		ClientID: "client",
+		Secret:   "secret",
+		UserName: "user",
+		Password: "password",
+	}, server.URL)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	return client
+}
+
+func TestUploadCategoryMediaFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "summer.jpg")
+	if err := os.WriteFile(filePath, []byte("image content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != categoryMediaFilePath {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("multipart file is missing: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+		if header.Filename != "summer.jpg" {
+			t.Errorf("unexpected file name %q", header.Filename)
+		}
+		if string(content) != "image content" {
+			t.Errorf("unexpected file content %q", content)
+		}
+
+		w.Header().Set("Location", "http://"+r.Host+categoryMediaFilePath+"/a/b/c/abc_summer.jpg")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	code, err := NewCategoryClient(client).UploadCategoryMediaFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "a/b/c/abc_summer.jpg" {
+		t.Errorf("unexpected media code %q", code)
+	}
+}
+
+func TestUploadCategoryMediaFileMissingLocation(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "summer.jpg")
+	if err := os.WriteFile(filePath, []byte("image content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	if _, err := NewCategoryClient(client).UploadCategoryMediaFile(filePath); err == nil {
+		t.Fatal("expected an error for a response without a media code")
+	}
+}
@@ -12,14 +12,12 @@ const (
 )
 
 type ChannelService struct {
-	goakeneo.ChannelService
-	client *goakeneo.Client
+	client *Client
 }
 
-func NewChannelClient(client *goakeneo.Client) *ChannelService {
+func NewChannelClient(client *Client) *ChannelService {
 	return &ChannelService{
-		ChannelService: client.Channel,
-		client:         client,
+		client: client,
 	}
 }
 
@@ -59,3 +57,7 @@ func (a *ChannelService) GetChannel(code string) (*goakeneo.Channel, error) {
 	}
 	return response, nil
 }
+
+func (a *ChannelService) ListChannels() ([]goakeneo.Channel, error) {
+	return listAll[goakeneo.Channel](a.client, channelPath, nil)
+}
@@ -0,0 +1,541 @@
+package akeneox
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+const (
+	authTokenPath = "/api/oauth/v1/token"
+
+	defaultHTTPTimeout    = 30 * time.Second
+	defaultRetry          = 2
+	defaultRetryWaitTime  = 3 * time.Second
+	defaultPageSize       = 100
+	defaultContentType    = "application/json"
+	collectionContentType = "application/vnd.akeneo.collection+json"
+	collectionBatchSize   = 100
+	defaultUserAgent      = "terraform-provider-akeneo"
+	tokenRefreshThreshold = 5 * time.Minute
+)
+
+// Client is the Akeneo REST API client used by the akeneox services.
+//
+// goakeneo keeps its token and HTTP client private and only supports
+// GET, POST and PATCH with JSON bodies, so this client handles
+// authentication itself and exposes the request helpers the provider needs.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	connector  goakeneo.Connector
+
+	authMu       sync.Mutex
+	token        string
+	refreshToken string
+	tokenExp     time.Time
+	tokenCache   TokenCache
+	// rejectedToken is the last access token the API rejected, so it is not
+	// loaded from the token cache again.
+	rejectedToken string
+	// staticToken is set for pre-issued access tokens, which the client can
+	// not refresh.
+	staticToken bool
+}
+
+// Option is a client option function.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for all requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a new client and authenticates it against the API.
+func NewClient(connector goakeneo.Connector, baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	c := &Client{
+		baseURL: u,
+		httpClient: &http.Client{
+			Timeout: defaultHTTPTimeout,
+		},
+		connector: connector,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	switch {
+	case connector.ClientID == "":
+		return nil, errors.New("client ID is empty")
+	case connector.Secret == "":
+		return nil, errors.New("client secret is empty")
+	case connector.UserName == "":
+		return nil, errors.New("username is empty")
+	case connector.Password == "":
+		return nil, errors.New("password is empty")
+	}
+
+	if err := c.autoRefreshToken(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NewClientWithAccessToken creates a new client authenticating with a
+// pre-issued access token, e.g. the token of a connected app.
+func NewClientWithAccessToken(accessToken string, baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if accessToken == "" {
+		return nil, errors.New("access token is empty")
+	}
+
+	c := &Client{
+		baseURL: u,
+		httpClient: &http.Client{
+			Timeout: defaultHTTPTimeout,
+		},
+		token:       accessToken,
+		staticToken: true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Error is returned when the API responds with a non-successful status code.
+type Error struct {
+	StatusCode int
+	Message    string
+	Errors     []goakeneo.ValidationError
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("request error (%d): %s", e.StatusCode, msg)
+	}
+
+	details := make([]string, len(e.Errors))
+	for i, v := range e.Errors {
+		details[i] = fmt.Sprintf("attribute '%s', property '%s': %s", v.Attribute, v.Property, v.Message)
+	}
+	return fmt.Sprintf("request error (%d): %s: %s", e.StatusCode, msg, strings.Join(details, "; "))
+}
+
+// IsNotFound reports whether err is an API error with the 404 status code.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// GET creates a get request and executes it, result must be a pointer.
+func (c *Client) GET(relPath string, query url.Values, data, result any) error {
+	_, err := c.doJSON(http.MethodGet, relPath, query, data, result)
+	return err
+}
+
+// POST creates a post request and executes it, result must be a pointer.
+func (c *Client) POST(relPath string, query url.Values, data, result any) error {
+	_, err := c.doJSON(http.MethodPost, relPath, query, data, result)
+	return err
+}
+
+// PATCH creates a patch request and executes it, result must be a pointer.
+func (c *Client) PATCH(relPath string, query url.Values, data, result any) error {
+	_, err := c.doJSON(http.MethodPatch, relPath, query, data, result)
+	return err
+}
+
+// DELETE creates a delete request and executes it.
+func (c *Client) DELETE(relPath string, query url.Values) error {
+	_, err := c.doJSON(http.MethodDelete, relPath, query, nil, nil)
+	return err
+}
+
+// PatchCollection sends the items as a collection patch request, one JSON
+// document per line, in batches of the maximum collection size. The API answers
+// with one status line per item.
+func (c *Client) PatchCollection(relPath string, items []any) ([]CollectionResponseLine, error) {
+	lines := make([]CollectionResponseLine, 0, len(items))
+
+	for start := 0; start < len(items); start += collectionBatchSize {
+		end := min(start+collectionBatchSize, len(items))
+
+		body := new(bytes.Buffer)
+		for _, item := range items[start:end] {
+			b, err := json.Marshal(item)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode request body: %w", err)
+			}
+			body.Write(b)
+			body.WriteByte('\n')
+		}
+
+		_, respBody, err := c.doRaw(http.MethodPatch, relPath, nil, body.Bytes(), collectionContentType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, raw := range bytes.Split(respBody, []byte("\n")) {
+			if len(bytes.TrimSpace(raw)) == 0 {
+				continue
+			}
+			line := CollectionResponseLine{}
+			if err := json.Unmarshal(raw, &line); err != nil {
+				return nil, fmt.Errorf("unable to decode response body: %w", err)
+			}
+			line.Line += start
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, nil
+}
+
+// Upload sends a multipart/form-data post request with the given fields and
+// file, the API answers media uploads with the media code in the Location header.
+func (c *Client) Upload(relPath string, fields map[string]string, fileField, fileName string, file io.Reader) (http.Header, error) {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, fmt.Errorf("unable to encode request body: %w", err)
+		}
+	}
+
+	part, err := w.CreateFormFile(fileField, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode request body: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("unable to encode request body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("unable to encode request body: %w", err)
+	}
+
+	return c.do(http.MethodPost, relPath, nil, body.Bytes(), w.FormDataContentType(), nil)
+}
+
+func (c *Client) doJSON(method, relPath string, query url.Values, data, result any) (http.Header, error) {
+	var body []byte
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode request body: %w", err)
+		}
+		body = b
+	}
+
+	return c.do(method, relPath, query, body, defaultContentType, result)
+}
+
+func (c *Client) do(method, relPath string, query url.Values, body []byte, contentType string, result any) (http.Header, error) {
+	header, respBody, err := c.doRaw(method, relPath, query, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return nil, fmt.Errorf("unable to decode response body: %w", err)
+		}
+	}
+
+	return header, nil
+}
+
+func (c *Client) doRaw(method, relPath string, query url.Values, body []byte, contentType string) (http.Header, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := c.accessToken()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := c.send(method, relPath, query, body, contentType, "Bearer "+token)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		respBody, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read response body: %w", err)
+		}
+
+		// A cached token can be revoked before it expires, e.g. when the
+		// password of the user changes, so it is dropped and the request is
+		// sent once more with a newly granted token.
+		if res.StatusCode == http.StatusUnauthorized && attempt == 0 && !c.staticToken {
+			c.dropToken(token)
+			continue
+		}
+
+		if res.StatusCode >= http.StatusBadRequest {
+			return nil, nil, newError(res.StatusCode, respBody)
+		}
+
+		return res.Header, respBody, nil
+	}
+}
+
+// send executes the request, retrying when the API rate limit is hit.
+func (c *Client) send(method, relPath string, query url.Values, body []byte, contentType string, authorization string) (*http.Response, error) {
+	rel, err := url.Parse(relPath)
+	if err != nil {
+		return nil, err
+	}
+	u := c.baseURL.ResolveReference(rel)
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", defaultContentType)
+		req.Header.Set("User-Agent", defaultUserAgent)
+		req.Header.Set("Authorization", authorization)
+		if body != nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", method, relPath, err)
+		}
+
+		if res.StatusCode != http.StatusTooManyRequests || attempt >= defaultRetry {
+			return res, nil
+		}
+
+		wait := defaultRetryWaitTime
+		if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			wait = time.Duration(s) * time.Second
+		}
+		res.Body.Close()
+		time.Sleep(wait)
+	}
+}
+
+func newError(statusCode int, body []byte) *Error {
+	errResp := goakeneo.ErrorResponse{}
+	_ = json.Unmarshal(body, &errResp)
+
+	return &Error{
+		StatusCode: statusCode,
+		Message:    errResp.Message,
+		Errors:     errResp.Errors,
+	}
+}
+
+type authResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (c *Client) grantByPassword() error {
+	return c.grant(map[string]string{
+		"grant_type": "password",
+		"username":   c.connector.UserName,
+		"password":   c.connector.Password,
+	})
+}
+
+func (c *Client) grantByRefreshToken() error {
+	return c.grant(map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": c.refreshToken,
+	})
+}
+
+func (c *Client) grant(request map[string]string) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(c.connector.ClientID + ":" + c.connector.Secret))
+	res, err := c.send(http.MethodPost, authTokenPath, nil, body, defaultContentType, "Basic "+credentials)
+	if err != nil {
+		return fmt.Errorf("unable to authenticate to the Akeneo API: %w", err)
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("unable to authenticate to the Akeneo API: %w", err)
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unable to authenticate to the Akeneo API: %w", newError(res.StatusCode, respBody))
+	}
+
+	result := authResponse{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("invalid authentication response from the Akeneo API: %w", err)
+	}
+	if result.AccessToken == "" || result.ExpiresIn == 0 {
+		return errors.New("invalid authentication response from the Akeneo API")
+	}
+
+	c.token = result.AccessToken
+	c.refreshToken = result.RefreshToken
+	c.tokenExp = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+
+	if c.tokenCache != nil {
+		// The cache only saves authentications, the client works without it.
+		_ = c.tokenCache.Store(c.tokenCacheKey(), Token{
+			AccessToken:  c.token,
+			RefreshToken: c.refreshToken,
+			ExpiresAt:    c.tokenExp,
+		})
+	}
+	return nil
+}
+
+// tokenCacheKey returns the key of the client's tokens in the token cache.
+// Tokens are granted to a user, so the key includes a hash of the username.
+func (c *Client) tokenCacheKey() string {
+	user := sha256.Sum256([]byte(c.connector.UserName))
+	return c.baseURL.Host + "|" + c.connector.ClientID + "|" + hex.EncodeToString(user[:])
+}
+
+// loadCachedToken uses the cached token when it expires later than the
+// current token, e.g. because another client refreshed it.
+func (c *Client) loadCachedToken() {
+	if c.tokenCache == nil {
+		return
+	}
+
+	token, ok, err := c.tokenCache.Load(c.tokenCacheKey())
+	if err != nil || !ok || token.AccessToken == c.rejectedToken || !token.ExpiresAt.After(c.tokenExp) {
+		return
+	}
+
+	c.token = token.AccessToken
+	c.refreshToken = token.RefreshToken
+	c.tokenExp = token.ExpiresAt
+}
+
+func (c *Client) tokenValid() bool {
+	return c.token != "" && time.Now().Add(tokenRefreshThreshold).Before(c.tokenExp)
+}
+
+// autoRefreshToken authenticates the client, and refreshes the access token
+// shortly before it expires so long runs do not fail with an expired token.
+func (c *Client) autoRefreshToken() error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.staticToken || c.tokenValid() {
+		return nil
+	}
+
+	c.loadCachedToken()
+	if c.tokenValid() {
+		return nil
+	}
+
+	if c.refreshToken != "" {
+		if err := c.grantByRefreshToken(); err == nil {
+			return nil
+		}
+	}
+
+	return c.grantByPassword()
+}
+
+// accessToken returns the access token for the next request, authenticating
+// the client when needed.
+func (c *Client) accessToken() (string, error) {
+	if err := c.autoRefreshToken(); err != nil {
+		return "", err
+	}
+
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	return c.token, nil
+}
+
+// dropToken discards an access token rejected by the API, so the next request
+// grants a new one. A token already replaced by another request is kept.
+func (c *Client) dropToken(token string) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.token != token {
+		return
+	}
+	c.rejectedToken = token
+	c.token = ""
+	c.tokenExp = time.Time{}
+}
+
+type listResponse[T any] struct {
+	Links    goakeneo.Links `json:"_links"`
+	Embedded struct {
+		Items []T `json:"items"`
+	} `json:"_embedded"`
+}
+
+// listAll fetches every page of a paginated list endpoint.
+func listAll[T any](c *Client, relPath string, query url.Values) ([]T, error) {
+	items := make([]T, 0)
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if query.Get("limit") == "" {
+		query.Set("limit", strconv.Itoa(defaultPageSize))
+	}
+
+	for {
+		response := new(listResponse[T])
+		if err := c.GET(relPath, query, nil, response); err != nil {
+			return nil, err
+		}
+		items = append(items, response.Embedded.Items...)
+
+		if !response.Links.HasNext() {
+			return items, nil
+		}
+		query = response.Links.NextOptions()
+	}
+}
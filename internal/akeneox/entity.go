@@ -11,6 +11,18 @@ type AttributeGroup struct {
 	Labels     map[string]string `json:"labels,omitempty" mapstructure:"labels"`
 }
 
+// ProductModel is the struct for an akeneo product model sent to the API.
+// Unlike goakeneo.ProductModel, the parent and the categories are always sent,
+// so they can be removed from a product model.
+type ProductModel struct {
+	Code          string                             `json:"code,omitempty" mapstructure:"code"`
+	Family        string                             `json:"family,omitempty" mapstructure:"family"`
+	FamilyVariant string                             `json:"family_variant,omitempty" mapstructure:"family_variant"`
+	Parent        *string                            `json:"parent" mapstructure:"parent"`
+	Categories    []string                           `json:"categories" mapstructure:"categories"`
+	Values        map[string][]goakeneo.ProductValue `json:"values,omitempty" mapstructure:"values"`
+}
+
 type MeasurementUnitConversion struct {
 	Operator string `json:"operator,omitempty" mapstructure:"operator"`
 	Value    string `json:"value,omitempty" mapstructure:"value"`
@@ -37,9 +49,61 @@ type MeasurementFamilyPatchResponse struct {
 	Errors     []goakeneo.ValidationError `json:"errors,omitempty"`
 }
 
+// CollectionResponseLine is the status of a single item of a collection patch request.
+type CollectionResponseLine struct {
+	Line       int                        `json:"line"`
+	Code       string                     `json:"code"`
+	StatusCode int                        `json:"status_code"`
+	Message    string                     `json:"message,omitempty"`
+	Errors     []goakeneo.ValidationError `json:"errors,omitempty"`
+}
+
+// IsError reports whether the item failed to be created or updated.
+func (l CollectionResponseLine) IsError() bool {
+	return l.StatusCode >= 400
+}
+
 type AssociationType struct {
 	Code         string            `json:"code,omitempty" mapstructure:"code"`
 	Labels       map[string]string `json:"labels,omitempty" mapstructure:"labels"`
 	IsQuantified *bool             `json:"is_quantified,omitempty" mapstructure:"is_quantified"`
 	IsTwoWay     *bool             `json:"is_two_way,omitempty" mapstructure:"is_two_way"`
 }
+
+// Category is the struct for an akeneo category. goakeneo does not export
+// the category value type, so the category is redefined here.
+type Category struct {
+	Code                string                   `json:"code,omitempty" mapstructure:"code"`
+	Parent              *string                  `json:"parent,omitempty" mapstructure:"parent"`
+	Updated             *string                  `json:"updated,omitempty" mapstructure:"updated"`
+	Position            *int                     `json:"position,omitempty" mapstructure:"position"`
+	Labels              map[string]string        `json:"labels,omitempty" mapstructure:"labels"`
+	Values              map[string]CategoryValue `json:"values,omitempty" mapstructure:"values"`
+	ChannelRequirements []string                 `json:"channel_requirements,omitempty" mapstructure:"channel_requirements"`
+}
+
+// CategoryValue is the struct for an akeneo category value, values are keyed
+// by the attribute code followed by the channel and locale separated by "|".
+type CategoryValue struct {
+	Data          any     `json:"data" mapstructure:"data"`
+	Type          string  `json:"type,omitempty" mapstructure:"type"`
+	Channel       *string `json:"channel" mapstructure:"channel"`
+	Locale        *string `json:"locale" mapstructure:"locale"`
+	AttributeCode string  `json:"attribute_code,omitempty" mapstructure:"attribute_code"`
+}
+
+type CategoryTemplateAttribute struct {
+	Code          string            `json:"code,omitempty" mapstructure:"code"`
+	Type          string            `json:"type,omitempty" mapstructure:"type"`
+	IsLocalizable bool              `json:"is_localizable" mapstructure:"is_localizable"`
+	IsScopable    bool              `json:"is_scopable" mapstructure:"is_scopable"`
+	IsRequired    bool              `json:"is_required" mapstructure:"is_required"`
+	Labels        map[string]string `json:"labels,omitempty" mapstructure:"labels"`
+}
+
+type CategoryTemplate struct {
+	Code         string                      `json:"code,omitempty" mapstructure:"code"`
+	CategoryTree string                      `json:"category_tree,omitempty" mapstructure:"category_tree"`
+	Labels       map[string]string           `json:"labels,omitempty" mapstructure:"labels"`
+	Attributes   []CategoryTemplateAttribute `json:"attributes,omitempty" mapstructure:"attributes"`
+}
@@ -2,24 +2,52 @@ package akeneox
 
 import (
 	"fmt"
+
 	goakeneo "github.com/ezifyio/go-akeneo"
 )
 
 const (
-	familyPath       = "/api/rest/v1/families"
-	familySinglePath = "/api/rest/v1/families/%s"
+	familyPath              = "/api/rest/v1/families"
+	familySinglePath        = "/api/rest/v1/families/%s"
+	familyVariantPath       = "/api/rest/v1/families/%s/variants"
+	familyVariantSinglePath = "/api/rest/v1/families/%s/variants/%s"
 )
 
 type FamilyService struct {
-	goakeneo.FamilyService
-	client *goakeneo.Client
+	client *Client
 }
 
-func NewFamilyClient(client *goakeneo.Client) *FamilyService {
+func NewFamilyClient(client *Client) *FamilyService {
 	return &FamilyService{
-		FamilyService: client.Family,
-		client:        client,
+		client: client,
+	}
+}
+
+func (a *FamilyService) GetFamily(code string) (*goakeneo.Family, error) {
+	response := new(goakeneo.Family)
+	err := a.client.GET(
+		fmt.Sprintf(familySinglePath, code),
+		nil,
+		nil,
+		response,
+	)
+	if err != nil {
+		return nil, err
 	}
+	return response, nil
+}
+
+func (a *FamilyService) ListFamilies() ([]goakeneo.Family, error) {
+	return listAll[goakeneo.Family](a.client, familyPath, nil)
+}
+
+func (a *FamilyService) CreateFamily(family goakeneo.Family) error {
+	return a.client.POST(
+		familyPath,
+		nil,
+		family,
+		nil,
+	)
 }
 
 func (a *FamilyService) UpdateFamily(family goakeneo.Family) (*goakeneo.Family, error) {
@@ -35,3 +63,35 @@ func (a *FamilyService) UpdateFamily(family goakeneo.Family) (*goakeneo.Family,
 	}
 	return response, nil
 }
+
+func (a *FamilyService) GetFamilyVariant(familyCode string, code string) (*goakeneo.FamilyVariant, error) {
+	response := new(goakeneo.FamilyVariant)
+	err := a.client.GET(
+		fmt.Sprintf(familyVariantSinglePath, familyCode, code),
+		nil,
+		nil,
+		response,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (a *FamilyService) CreateFamilyVariant(familyCode string, variant goakeneo.FamilyVariant) error {
+	return a.client.POST(
+		fmt.Sprintf(familyVariantPath, familyCode),
+		nil,
+		variant,
+		nil,
+	)
+}
+
+func (a *FamilyService) UpdateFamilyVariant(familyCode string, code string, variant goakeneo.FamilyVariant) error {
+	return a.client.PATCH(
+		fmt.Sprintf(familyVariantSinglePath, familyCode, code),
+		nil,
+		variant,
+		nil,
+	)
+}
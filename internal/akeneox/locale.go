@@ -0,0 +1,31 @@
+package akeneox
+
+import (
+	"net/url"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+const (
+	localePath = "/api/rest/v1/locales"
+)
+
+type LocaleService struct {
+	client *Client
+}
+
+func NewLocaleClient(client *Client) *LocaleService {
+	return &LocaleService{
+		client: client,
+	}
+}
+
+// GetActivatedLocales returns the locales activated by at least one channel.
+func (a *LocaleService) GetActivatedLocales() ([]goakeneo.Locale, error) {
+	search := make(goakeneo.SearchFilter)
+	search.Add("enabled", "=", true)
+
+	return listAll[goakeneo.Locale](a.client, localePath, url.Values{
+		"search": []string{search.String()},
+	})
+}
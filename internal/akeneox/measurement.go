@@ -1,25 +1,33 @@
 package akeneox
 
 import (
-	goakeneo "github.com/ezifyio/go-akeneo"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
 )
 
 const (
 	measurementFamilyPath = "/api/rest/v1/measurement-families"
+
+	// measurementFamilyBatchDelay is how long updates are collected before they
+	// are sent together, long enough for resources applied in parallel to join.
+	measurementFamilyBatchDelay = 100 * time.Millisecond
 )
 
 type MeasurementFamilyService struct {
-	client *goakeneo.Client
+	client *Client
 }
 
-func NewMeasurementFamilyClient(client *goakeneo.Client) *MeasurementFamilyService {
+func NewMeasurementFamilyClient(client *Client) *MeasurementFamilyService {
 	return &MeasurementFamilyService{
 		client: client,
 	}
 }
 
-func (a *MeasurementFamilyService) GetMeasurementFamily(code string) (*MeasurementFamily, error) {
-	data := new(MeasurementFamily)
+// ListMeasurementFamilies returns all measurement families, the API has no
+// endpoint for a single measurement family.
+func (a *MeasurementFamilyService) ListMeasurementFamilies() ([]MeasurementFamily, error) {
 	response := new([]MeasurementFamily)
 	err := a.client.GET(
 		measurementFamilyPath,
@@ -30,16 +38,7 @@ func (a *MeasurementFamilyService) GetMeasurementFamily(code string) (*Measureme
 	if err != nil {
 		return nil, err
 	}
-
-	for _, r := range *response {
-		if r.Code == code {
-			selected := r
-			data = &selected
-			break
-		}
-	}
-
-	return data, nil
+	return *response, nil
 }
 
 func (a *MeasurementFamilyService) UpdateMeasurementFamilies(families []MeasurementFamily) (*[]MeasurementFamilyPatchResponse, error) {
@@ -55,3 +54,135 @@ func (a *MeasurementFamilyService) UpdateMeasurementFamilies(families []Measurem
 	}
 	return response, nil
 }
+
+// MeasurementFamilyStore shares measurement families between resources for the
+// duration of a provider run. The family list is downloaded once and kept up to
+// date with successful updates, and updates made in parallel are sent to the
+// API as a single PATCH request.
+type MeasurementFamilyStore struct {
+	service *MeasurementFamilyService
+
+	loadMu   sync.Mutex
+	mu       sync.Mutex
+	families map[string]MeasurementFamily
+	pending  []*measurementFamilyUpdate
+}
+
+type measurementFamilyUpdate struct {
+	family MeasurementFamily
+	result *MeasurementFamilyPatchResponse
+	err    error
+	done   chan struct{}
+}
+
+func NewMeasurementFamilyStore(client *Client) *MeasurementFamilyStore {
+	return &MeasurementFamilyStore{
+		service: NewMeasurementFamilyClient(client),
+	}
+}
+
+// GetMeasurementFamily returns the measurement family with the given code, or
+// a not found error when it does not exist.
+func (s *MeasurementFamilyStore) GetMeasurementFamily(code string) (*MeasurementFamily, error) {
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	family, ok := s.families[code]
+	if !ok {
+		return nil, &Error{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("measurement family %s does not exist", code),
+		}
+	}
+	return &family, nil
+}
+
+// UpdateMeasurementFamily creates or updates the measurement family and returns
+// its own status from the batched PATCH response. An error is only returned when
+// the whole request failed.
+func (s *MeasurementFamilyStore) UpdateMeasurementFamily(family MeasurementFamily) (*MeasurementFamilyPatchResponse, error) {
+	update := &measurementFamilyUpdate{
+		family: family,
+		done:   make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	s.pending = append(s.pending, update)
+	if len(s.pending) == 1 {
+		time.AfterFunc(measurementFamilyBatchDelay, s.flush)
+	}
+	s.mu.Unlock()
+
+	<-update.done
+	return update.result, update.err
+}
+
+func (s *MeasurementFamilyStore) load() error {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+
+	s.mu.Lock()
+	loaded := s.families != nil
+	s.mu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	families, err := s.service.ListMeasurementFamilies()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.families = make(map[string]MeasurementFamily, len(families))
+	for _, family := range families {
+		s.families[family.Code] = family
+	}
+	return nil
+}
+
+func (s *MeasurementFamilyStore) flush() {
+	s.mu.Lock()
+	updates := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	families := make([]MeasurementFamily, len(updates))
+	for i, update := range updates {
+		families[i] = update.family
+	}
+
+	response, err := s.service.UpdateMeasurementFamilies(families)
+
+	results := make(map[string]MeasurementFamilyPatchResponse)
+	if response != nil {
+		for _, result := range *response {
+			results[result.Code] = result
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, update := range updates {
+		result, ok := results[update.family.Code]
+		switch {
+		case err != nil:
+			update.err = err
+		case !ok:
+			update.err = fmt.Errorf("missing status of measurement family %s in the response", update.family.Code)
+		default:
+			update.result = &result
+			if result.StatusCode < 300 && s.families != nil {
+				s.families[update.family.Code] = update.family
+			}
+		}
+		close(update.done)
+	}
+}
@@ -0,0 +1,107 @@
+package akeneox
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+func TestMeasurementFamilyStoreLoadsOnce(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]MeasurementFamily{
+			{Code: "Length", StandardUnitCode: "METER"},
+			{Code: "Weight", StandardUnitCode: "GRAM"},
+		})
+	})
+
+	store := NewMeasurementFamilyStore(client)
+	for _, code := range []string{"Length", "Weight", "Length"} {
+		family, err := store.GetMeasurementFamily(code)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if family.Code != code {
+			t.Errorf("expected family %s, got %s", code, family.Code)
+		}
+	}
+
+	if _, err := store.GetMeasurementFamily("Missing"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected a single list request, got %d", got)
+	}
+}
+
+func TestMeasurementFamilyStoreBatchesUpdates(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != measurementFamilyPath {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests.Add(1)
+
+		var families []MeasurementFamily
+		if err := json.NewDecoder(r.Body).Decode(&families); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+
+		response := make([]MeasurementFamilyPatchResponse, len(families))
+		for i, family := range families {
+			response[i] = MeasurementFamilyPatchResponse{Code: family.Code, StatusCode: http.StatusNoContent}
+			if family.Code == "Invalid" {
+				response[i] = MeasurementFamilyPatchResponse{
+					Code:       family.Code,
+					StatusCode: http.StatusUnprocessableEntity,
+					Errors:     []goakeneo.ValidationError{{Property: "standard_unit_code", Message: "invalid"}},
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	store := NewMeasurementFamilyStore(client)
+	codes := []string{"Length", "Invalid", "Weight"}
+	results := make([]*MeasurementFamilyPatchResponse, len(codes))
+
+	var wg sync.WaitGroup
+	for i, code := range codes {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			result, err := store.UpdateMeasurementFamily(MeasurementFamily{Code: code})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = result
+		}(i, code)
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected a single patch request, got %d", got)
+	}
+
+	for i, code := range codes {
+		if results[i] == nil || results[i].Code != code {
+			t.Fatalf("expected status of %s, got %+v", code, results[i])
+		}
+	}
+	if results[1].StatusCode != http.StatusUnprocessableEntity || len(results[1].Errors) != 1 {
+		t.Errorf("expected validation error of Invalid, got %+v", results[1])
+	}
+	if results[0].StatusCode != http.StatusNoContent {
+		t.Errorf("expected success of Length, got %+v", results[0])
+	}
+}
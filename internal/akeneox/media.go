@@ -0,0 +1,43 @@
+package akeneox
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// uploadMediaFile uploads the local file to the media endpoint and returns the
+// media code the API assigned to it.
+func uploadMediaFile(c *Client, relPath string, fields map[string]string, filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	header, err := c.Upload(relPath, fields, "file", filepath.Base(filePath), f)
+	if err != nil {
+		return "", err
+	}
+
+	return mediaCodeFromLocation(header, relPath)
+}
+
+// mediaCodeFromLocation extracts the media code from the Location header,
+// e.g. ".../media-files/7/1/3/3/7133...e8_image.jpg" for the media-files endpoint.
+func mediaCodeFromLocation(header http.Header, relPath string) (string, error) {
+	location, err := url.Parse(header.Get("Location"))
+	if err != nil {
+		return "", err
+	}
+
+	_, code, found := strings.Cut(location.Path, relPath+"/")
+	if !found || code == "" {
+		return "", errors.New("media code is missing in the upload response")
+	}
+
+	return code, nil
+}
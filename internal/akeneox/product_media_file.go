@@ -0,0 +1,41 @@
+package akeneox
+
+import (
+	"fmt"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+const (
+	productMediaFilePath       = "/api/rest/v1/media-files"
+	productMediaFileSinglePath = "/api/rest/v1/media-files/%s"
+)
+
+type ProductMediaFileService struct {
+	client *Client
+}
+
+func NewProductMediaFileClient(client *Client) *ProductMediaFileService {
+	return &ProductMediaFileService{
+		client: client,
+	}
+}
+
+// UploadProductMediaFile uploads a local file and returns its media code.
+func (a *ProductMediaFileService) UploadProductMediaFile(filePath string) (string, error) {
+	return uploadMediaFile(a.client, productMediaFilePath, nil, filePath)
+}
+
+func (a *ProductMediaFileService) GetProductMediaFile(code string) (*goakeneo.MediaFile, error) {
+	response := new(goakeneo.MediaFile)
+	err := a.client.GET(
+		fmt.Sprintf(productMediaFileSinglePath, code),
+		nil,
+		nil,
+		response,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
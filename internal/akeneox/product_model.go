@@ -0,0 +1,61 @@
+package akeneox
+
+import (
+	"fmt"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+const (
+	productModelPath       = "/api/rest/v1/product-models"
+	productModelSinglePath = "/api/rest/v1/product-models/%s"
+)
+
+type ProductModelService struct {
+	client *Client
+}
+
+func NewProductModelClient(client *Client) *ProductModelService {
+	return &ProductModelService{
+		client: client,
+	}
+}
+
+func (a *ProductModelService) CreateProductModel(productModel ProductModel) error {
+	return a.client.POST(
+		productModelPath,
+		nil,
+		productModel,
+		nil,
+	)
+}
+
+func (a *ProductModelService) UpdateProductModel(productModel ProductModel) error {
+	return a.client.PATCH(
+		fmt.Sprintf(productModelSinglePath, productModel.Code),
+		nil,
+		productModel,
+		nil,
+	)
+}
+
+func (a *ProductModelService) GetProductModel(code string) (*goakeneo.ProductModel, error) {
+	response := new(goakeneo.ProductModel)
+	err := a.client.GET(
+		fmt.Sprintf(productModelSinglePath, code),
+		nil,
+		nil,
+		response,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (a *ProductModelService) DeleteProductModel(code string) error {
+	return a.client.DELETE(
+		fmt.Sprintf(productModelSinglePath, code),
+		nil,
+	)
+}
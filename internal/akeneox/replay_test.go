@@ -0,0 +1,109 @@
+package akeneox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox/akeneoxtest"
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+// newRecordedClient returns a client replaying the fixture of the given name
+// from testdata/fixtures.
+//
+// With AKENEO_RECORD set the fixture is recorded instead, against the PIM at
+// AKENEO_HOST authenticated with AKENEO_CLIENT_ID, AKENEO_CLIENT_SECRET,
+// AKENEO_USERNAME and AKENEO_PASSWORD. The test then has to create every
+// object it uses, so it can be recorded again against a fresh PIM.
+func newRecordedClient(t *testing.T, name string) *Client {
+	t.Helper()
+
+	path := filepath.Join("testdata", "fixtures", name+".yaml")
+	mode := akeneoxtest.ModeReplay
+	baseURL := "https://" + akeneoxtest.RecordedHost
+	connector := goakeneo.Connector{
+		ClientID: akeneoxtest.ClientID,
+		Secret:   akeneoxtest.Secret,
+		UserName: akeneoxtest.Username,
+		Password: akeneoxtest.Password,
+	}
+
+	if os.Getenv("AKENEO_RECORD") != "" {
+		mode = akeneoxtest.ModeRecord
+		baseURL = os.Getenv("AKENEO_HOST")
+		connector = goakeneo.Connector{
+			ClientID: os.Getenv("AKENEO_CLIENT_ID"),
+			Secret:   os.Getenv("AKENEO_CLIENT_SECRET"),
+			UserName: os.Getenv("AKENEO_USERNAME"),
+			Password: os.Getenv("AKENEO_PASSWORD"),
+		}
+	}
+
+	recorder, err := akeneoxtest.NewRecorder(path, mode)
+	if err != nil {
+		t.Fatalf("unable to create recorder: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			t.Errorf("unable to save fixture: %v", err)
+		}
+		if mode == akeneoxtest.ModeReplay && !t.Failed() {
+			for _, interaction := range recorder.Unused() {
+				t.Errorf("recorded request was not sent: %s %s", interaction.Request.Method, interaction.Request.URL)
+			}
+		}
+	})
+
+	client, err := NewClient(connector, baseURL, WithHTTPClient(recorder.Client()))
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	return client
+}
+
+func TestReplayAttributeOptions(t *testing.T) {
+	service := NewAttributeClient(newRecordedClient(t, "attribute_options"))
+
+	if err := service.CreateAttribute(goakeneo.Attribute{
+		Code:   "replay_color",
+		Type:   "pim_catalog_simpleselect",
+		Group:  "other",
+		Labels: map[string]string{"en_US": "Color"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines, err := service.UpdateAttributeOptions("replay_color", []goakeneo.AttributeOption{
+		{Code: "red", Attribute: "replay_color", Labels: map[string]string{"en_US": "Red"}},
+		{Code: "blue", Attribute: "replay_color", Labels: map[string]string{"en_US": "Blue"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range lines {
+		if line.StatusCode != 201 {
+			t.Errorf("expected option %s to be created, got status %d", line.Code, line.StatusCode)
+		}
+	}
+
+	options, err := service.ListAttributeOptions("replay_color")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(options) != 2 {
+		t.Errorf("expected 2 options, got %d", len(options))
+	}
+
+	option, err := service.GetAttributeOption("replay_color", "blue")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if option.Labels["en_US"] != "Blue" {
+		t.Errorf("expected label Blue, got %q", option.Labels["en_US"])
+	}
+
+	if _, err := service.GetAttributeOption("replay_color", "green"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package akeneox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Token is an OAuth token granted by the API.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// TokenCache stores the tokens of clients, so clients of the same API client
+// can share a token instead of each authenticating with the password grant.
+//
+// Tokens are stored under a key of the host, the client ID and the hash of the
+// username. Load reports false when no token is stored under the key.
+type TokenCache interface {
+	Load(key string) (Token, bool, error)
+	Store(key string, token Token) error
+}
+
+// WithTokenCache sets the cache the client loads its token from and stores
+// the granted tokens in.
+func WithTokenCache(cache TokenCache) Option {
+	return func(c *Client) {
+		c.tokenCache = cache
+	}
+}
+
+// MemoryTokenCache is a TokenCache keeping the tokens in memory, it is shared
+// by the clients created in the same process.
+type MemoryTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+// NewMemoryTokenCache creates an empty in-memory token cache.
+func NewMemoryTokenCache() *MemoryTokenCache {
+	return &MemoryTokenCache{
+		tokens: make(map[string]Token),
+	}
+}
+
+func (m *MemoryTokenCache) Load(key string) (Token, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[key]
+	return token, ok, nil
+}
+
+func (m *MemoryTokenCache) Store(key string, token Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tokens[key] = token
+	return nil
+}
+
+// FileTokenCache is a TokenCache keeping the tokens in files of a directory,
+// so they are shared across processes. The tokens are encrypted with AES-GCM
+// using the SHA-256 hash of the encryption key.
+type FileTokenCache struct {
+	dir  string
+	aead cipher.AEAD
+}
+
+// NewFileTokenCache creates a token cache storing the tokens in dir encrypted
+// with the key.
+func NewFileTokenCache(dir string, key string) (*FileTokenCache, error) {
+	if key == "" {
+		return nil, errors.New("token cache encryption key is empty")
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileTokenCache{
+		dir:  dir,
+		aead: aead,
+	}, nil
+}
+
+func (f *FileTokenCache) Load(key string) (Token, bool, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Token{}, false, nil
+	}
+	if err != nil {
+		return Token{}, false, fmt.Errorf("unable to read cached token: %w", err)
+	}
+
+	nonceSize := f.aead.NonceSize()
+	if len(data) < nonceSize {
+		return Token{}, false, errors.New("unable to decrypt cached token: file is too short")
+	}
+	// The key is authenticated as well, so a token file can not be used for
+	// another host or client.
+	plaintext, err := f.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(key))
+	if err != nil {
+		return Token{}, false, fmt.Errorf("unable to decrypt cached token: %w", err)
+	}
+
+	token := Token{}
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return Token{}, false, fmt.Errorf("unable to decode cached token: %w", err)
+	}
+	return token, true, nil
+}
+
+func (f *FileTokenCache) Store(key string, token Token) error {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, f.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := f.aead.Seal(nonce, nonce, plaintext, []byte(key))
+
+	if err := os.MkdirAll(f.dir, 0o700); err != nil {
+		return fmt.Errorf("unable to write cached token: %w", err)
+	}
+
+	// Write to a temporary file first, so concurrent processes never read a
+	// partially written token.
+	tmp, err := os.CreateTemp(f.dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("unable to write cached token: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write cached token: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write cached token: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		return fmt.Errorf("unable to write cached token: %w", err)
+	}
+	return nil
+}
+
+// path returns the file of the key, the key is hashed so the file names do
+// not reveal the host or the client ID.
+func (f *FileTokenCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(hash[:])+".token")
+}
@@ -0,0 +1,205 @@
+package akeneox
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox/akeneoxtest"
+	goakeneo "github.com/ezifyio/go-akeneo"
+)
+
+func newCachedClient(t *testing.T, server *akeneoxtest.Server, opts ...Option) *Client {
+	t.Helper()
+
+	client, err := NewClient(goakeneo.Connector{
+		ClientID: akeneoxtest.ClientID,
+		Secret:   akeneoxtest.Secret,
+		UserName: akeneoxtest.Username,
+		Password: akeneoxtest.Password,
+	}, server.URL, opts...)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	return client
+}
+
+func TestFileTokenCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileTokenCache(dir, "key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok, err := cache.Load("host|client"); ok || err != nil {
+		t.Fatalf("expected no cached token, got %v, %v", ok, err)
+	}
+
+	token := Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+	if err := cache.Store("host|client", token); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cached, ok, err := cache.Load("host|client")
+	if err != nil || !ok {
+		t.Fatalf("expected cached token, got %v, %v", ok, err)
+	}
+	if cached.AccessToken != token.AccessToken || cached.RefreshToken != token.RefreshToken || !cached.ExpiresAt.Equal(token.ExpiresAt) {
+		t.Errorf("expected token %v, got %v", token, cached)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if bytes.Contains(data, []byte("access")) || bytes.Contains(data, []byte("host")) {
+		t.Errorf("expected the token file to be encrypted")
+	}
+
+	other, _ := NewFileTokenCache(dir, "other key")
+	if _, _, err := other.Load("host|client"); err == nil {
+		t.Errorf("expected an error for the wrong encryption key")
+	}
+
+	// The token can not be loaded for another host or client.
+	if err := os.Rename(files[0], cache.path("other|client")); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cache.Load("other|client"); err == nil {
+		t.Errorf("expected an error for a token of another key")
+	}
+}
+
+func TestClientSharesCachedToken(t *testing.T) {
+	server := akeneoxtest.NewServer()
+	t.Cleanup(server.Close)
+
+	cache := NewMemoryTokenCache()
+	for i := 0; i < 3; i++ {
+		client := newCachedClient(t, server, WithTokenCache(cache))
+		if _, err := NewChannelClient(client).GetChannel("ecommerce"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if grants := server.Grants(); grants != 1 {
+		t.Errorf("expected 1 granted token, got %d", grants)
+	}
+}
+
+// grantRecorder records the grant types of the token requests.
+type grantRecorder struct {
+	grantTypes []string
+}
+
+func (g *grantRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == authTokenPath {
+		body, _ := io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		var request map[string]string
+		_ = json.Unmarshal(body, &request)
+		g.grantTypes = append(g.grantTypes, request["grant_type"])
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientRefreshesExpiringToken(t *testing.T) {
+	server := akeneoxtest.NewServer()
+	t.Cleanup(server.Close)
+	// The tokens expire within the refresh threshold, so the client refreshes
+	// them before every request.
+	server.TokenLifetime = time.Minute
+
+	recorder := &grantRecorder{}
+	cache := NewMemoryTokenCache()
+	client := newCachedClient(t, server, WithHTTPClient(&http.Client{Transport: recorder}), WithTokenCache(cache))
+
+	for i := 0; i < 2; i++ {
+		if _, err := NewChannelClient(client).GetChannel("ecommerce"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := []string{"password", "refresh_token", "refresh_token"}
+	if len(recorder.grantTypes) != len(expected) {
+		t.Fatalf("expected grants %v, got %v", expected, recorder.grantTypes)
+	}
+	for i := range expected {
+		if recorder.grantTypes[i] != expected[i] {
+			t.Errorf("expected grants %v, got %v", expected, recorder.grantTypes)
+			break
+		}
+	}
+
+	// The refreshed token is shared, its refresh token is the only one which
+	// was not used yet.
+	cached, ok, _ := cache.Load(client.tokenCacheKey())
+	if !ok || cached.AccessToken != client.token || cached.RefreshToken != client.refreshToken {
+		t.Errorf("expected the refreshed token to be cached, got %v", cached)
+	}
+}
+
+func TestClientGrantsNewTokenWhenCachedTokenIsRevoked(t *testing.T) {
+	server := akeneoxtest.NewServer()
+	t.Cleanup(server.Close)
+
+	recorder := &grantRecorder{}
+	cache := NewMemoryTokenCache()
+	newCachedClient(t, server, WithTokenCache(cache))
+	server.RevokeTokens()
+
+	// The second client loads the revoked token from the cache.
+	client := newCachedClient(t, server, WithHTTPClient(&http.Client{Transport: recorder}), WithTokenCache(cache))
+	if _, err := NewChannelClient(client).GetChannel("ecommerce"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The refresh token was revoked as well, so the client falls back to the
+	// password grant.
+	expected := []string{"refresh_token", "password"}
+	if len(recorder.grantTypes) != len(expected) {
+		t.Fatalf("expected grants %v, got %v", expected, recorder.grantTypes)
+	}
+	for i := range expected {
+		if recorder.grantTypes[i] != expected[i] {
+			t.Errorf("expected grants %v, got %v", expected, recorder.grantTypes)
+			break
+		}
+	}
+
+	cached, ok, _ := cache.Load(client.tokenCacheKey())
+	if !ok || cached.AccessToken != client.token {
+		t.Errorf("expected the new token to be cached, got %v", cached)
+	}
+}
+
+func TestTokenCacheKeyIncludesUsername(t *testing.T) {
+	baseURL, _ := url.Parse("https://akeneo.example.com")
+	key := func(username string) string {
+		client := &Client{
+			baseURL:   baseURL,
+			connector: goakeneo.Connector{ClientID: "client", UserName: username},
+		}
+		return client.tokenCacheKey()
+	}
+
+	if key("admin") == key("other") {
+		t.Errorf("expected different keys for different users, got %s", key("admin"))
+	}
+	if strings.Contains(key("admin"), "admin") {
+		t.Errorf("expected the username to be hashed, got %s", key("admin"))
+	}
+}
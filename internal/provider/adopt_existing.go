@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// adoptExistingAttribute is the schema of the adopt_existing flag, which lets
+// create take over an object that already exists in Akeneo.
+func adoptExistingAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Take over an object with the same code which already exists in Akeneo on create instead of failing. Importing the object is the preferred way to manage it.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+}
+
+// adoptExistingValue returns the flag with imported resources, which have no
+// value in the state, defaulted to false.
+func adoptExistingValue(v types.Bool) types.Bool {
+	if v.IsNull() || v.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return v
+}
+
+// addAlreadyExistsError reports an object which exists in Akeneo but is not
+// managed by this resource.
+func addAlreadyExistsError(diags *diag.Diagnostics, kind string, code string) {
+	diags.AddAttributeError(
+		path.Root("code"),
+		"The "+kind+" already exists",
+		"The "+kind+" "+code+" already exists in Akeneo. Import it into the state to manage it, "+
+			"or set adopt_existing = true to take it over on create.",
+	)
+}
@@ -7,11 +7,12 @@ import (
 	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -20,6 +21,7 @@ import (
 var _ resource.Resource = &AssociationTypeResource{}
 var _ resource.ResourceWithImportState = &AssociationTypeResource{}
 var _ resource.ResourceWithConfigure = &AssociationTypeResource{}
+var _ resource.ResourceWithModifyPlan = &AssociationTypeResource{}
 
 func NewAssociationTypeResource() resource.Resource {
 	return &AssociationTypeResource{}
@@ -28,14 +30,18 @@ func NewAssociationTypeResource() resource.Resource {
 // AssociationTypeResource defines the resource implementation.
 type AssociationTypeResource struct {
 	client *akeneox.AssociationTypeService
+	labels labelDefaults
 }
 
 // AssociationTypeResourceModel describes the resource data model.
 type AssociationTypeResourceModel struct {
-	Code         types.String `tfsdk:"code"`
-	Labels       types.Map    `tfsdk:"labels"`
-	IsQuantified types.Bool   `tfsdk:"is_quantified"`
-	IsTwoWay     types.Bool   `tfsdk:"is_two_way"`
+	Code            types.String `tfsdk:"code"`
+	Labels          types.Map    `tfsdk:"labels"`
+	Label           types.String `tfsdk:"label"`
+	EffectiveLabels types.Map    `tfsdk:"effective_labels"`
+	IsQuantified    types.Bool   `tfsdk:"is_quantified"`
+	IsTwoWay        types.Bool   `tfsdk:"is_two_way"`
+	AdoptExisting   types.Bool   `tfsdk:"adopt_existing"`
 }
 
 func (r *AssociationTypeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,14 +65,25 @@ func (r *AssociationTypeResource) Schema(ctx context.Context, req resource.Schem
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
 			"is_quantified": schema.BoolAttribute{
-				Description: "Whether the association type is a quantified association",
+				Description: "Whether the association type is a quantified association. Can not be changed once the association type is created. When not set, a new association type is not quantified and an adopted one keeps its value",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"is_two_way": schema.BoolAttribute{
-				Description: "Whether the association type is a two-way association",
+				Description: "Whether the association type is a two-way association. Can not be changed once the association type is created. When not set, a new association type is not two-way and an adopted one keeps its value",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"adopt_existing": adoptExistingAttribute(),
 		},
 	}
 }
@@ -95,9 +112,57 @@ func (r *AssociationTypeResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewAssociationTypeClient(data.Client)
 }
 
+func (r *AssociationTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+
+	// Flags can be freely set on create and there is nothing to check on destroy.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var data, state AssociationTypeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flags := []struct {
+		name    string
+		planned types.Bool
+		current types.Bool
+	}{
+		{"is_quantified", data.IsQuantified, state.IsQuantified},
+		{"is_two_way", data.IsTwoWay, state.IsTwoWay},
+	}
+
+	for _, f := range flags {
+		// Unset flags are not managed by the resource.
+		if f.planned.IsNull() || f.planned.IsUnknown() || f.current.IsNull() {
+			continue
+		}
+
+		if f.planned.ValueBool() != f.current.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Association type flag can not be changed",
+				fmt.Sprintf(
+					"Akeneo does not allow changing %s once the association type %s is created. "+
+						"Create a new association type with a different code instead.",
+					f.name,
+					data.Code.ValueString(),
+				),
+			)
+		}
+	}
+}
+
 func (r *AssociationTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data AssociationTypeResourceModel
 
@@ -114,7 +179,16 @@ func (r *AssociationTypeResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	err := r.client.UpdateAssociationTypes(*apiData)
+	existing, err := r.client.GetAssociationType(apiData.Code)
+	switch {
+	case err == nil && !data.AdoptExisting.ValueBool():
+		addAlreadyExistsError(&resp.Diagnostics, "association type", apiData.Code)
+		return
+	case err == nil:
+		err = r.client.UpdateAssociationType(*apiData)
+	case akeneox.IsNotFound(err):
+		err = r.client.CreateAssociationType(*apiData)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while creating a association type",
@@ -124,6 +198,14 @@ func (r *AssociationTypeResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// Unset flags are not sent, so an adopted association type keeps its flags.
+	var quantified, twoWay *bool
+	if existing != nil {
+		quantified, twoWay = existing.IsQuantified, existing.IsTwoWay
+	}
+	data.IsQuantified = associationTypeFlagValue(data.IsQuantified, quantified)
+	data.IsTwoWay = associationTypeFlagValue(data.IsTwoWay, twoWay)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -132,6 +214,15 @@ func (r *AssociationTypeResource) Create(ctx context.Context, req resource.Creat
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// associationTypeFlagValue returns the value of a flag after create, an unset
+// flag has the value of the adopted association type or false for a new one.
+func associationTypeFlagValue(planned types.Bool, existing *bool) types.Bool {
+	if !planned.IsUnknown() {
+		return planned
+	}
+	return types.BoolValue(existing != nil && *existing)
+}
+
 func (r *AssociationTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data AssociationTypeResourceModel
 
@@ -157,6 +248,7 @@ func (r *AssociationTypeResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	r.mapToTfObject(&resp.Diagnostics, &data, attrData)
+	data.AdoptExisting = adoptExistingValue(data.AdoptExisting)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -181,11 +273,13 @@ func (r *AssociationTypeResource) Update(ctx context.Context, req resource.Updat
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	err := r.client.UpdateAssociationTypes(*apiData)
+
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+	err := r.client.UpdateAssociationType(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error while creating a association type",
-			"An unexpected error occurred when creating association type. \n\n"+
+			"Error while updating a association type",
+			"An unexpected error occurred when updating association type. \n\n"+
 				"Akeneo API Error: "+err.Error(),
 		)
 		return
@@ -224,15 +318,7 @@ func (r *AssociationTypeResource) mapToApiObject(ctx context.Context, diags *dia
 		Code: data.Code.ValueString(),
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
-	}
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
 
 	if !(data.IsQuantified.IsNull() || data.IsQuantified.IsUnknown()) {
 		v := data.IsQuantified.ValueBool()
@@ -254,25 +340,13 @@ func (r *AssociationTypeResource) mapToApiObject(ctx context.Context, diags *dia
 func (r *AssociationTypeResource) mapToTfObject(respDiags *diag.Diagnostics, data *AssociationTypeResourceModel, apiData *akeneox.AssociationType) {
 	data.Code = types.StringValue(apiData.Code)
 
-	if len(apiData.Labels) > 0 {
-		elements := make(map[string]attr.Value, len(apiData.Labels))
-
-		for k, v := range apiData.Labels {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, apiData.Labels)
 
 	if apiData.IsQuantified != nil {
 		data.IsQuantified = types.BoolValue(*apiData.IsQuantified)
 	}
 
 	if apiData.IsTwoWay != nil {
-		data.IsQuantified = types.BoolValue(*apiData.IsTwoWay)
+		data.IsTwoWay = types.BoolValue(*apiData.IsTwoWay)
 	}
 }
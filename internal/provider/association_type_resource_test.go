@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAssociationTypeResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_association_type" "test" {
+  code   = "upsell"
+  labels = { en_US = "Upsell" }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_association_type.test", "code", "upsell"),
+					resource.TestCheckResourceAttr("akeneo_association_type.test", "labels.en_US", "Upsell"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_association_type.test",
+				ImportState:                          true,
+				ImportStateId:                        "upsell",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_association_type" "test" {
+  code   = "upsell"
+  labels = { en_US = "Upsell", fr_FR = "Vente incitative" }
+}
+
+resource "akeneo_association_type" "pack" {
+  code          = "pack"
+  is_quantified = true
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_association_type.test", "labels.%", "2"),
+					resource.TestCheckResourceAttr("akeneo_association_type.pack", "is_quantified", "true"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_association_type.test", "akeneo_association_type.pack"),
+			},
+			// Adopting keeps the flags which are not set
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_association_type" "pack" {
+  code           = "pack"
+  adopt_existing = true
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_association_type.pack", "is_quantified", "true"),
+					resource.TestCheckResourceAttr("akeneo_association_type.pack", "is_two_way", "false"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_association_type.pack"),
+			},
+		},
+	})
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssociationTypesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AssociationTypesDataSource{}
+
+func NewAssociationTypesDataSource() datasource.DataSource {
+	return &AssociationTypesDataSource{}
+}
+
+// AssociationTypesDataSource defines the data source implementation.
+type AssociationTypesDataSource struct {
+	client *akeneox.AssociationTypeService
+}
+
+type AssociationTypesItemModel struct {
+	Code         types.String `tfsdk:"code"`
+	Labels       types.Map    `tfsdk:"labels"`
+	IsQuantified types.Bool   `tfsdk:"is_quantified"`
+	IsTwoWay     types.Bool   `tfsdk:"is_two_way"`
+}
+
+// AssociationTypesDataSourceModel describes the data source data model.
+type AssociationTypesDataSourceModel struct {
+	Codes            types.Set                   `tfsdk:"codes"`
+	AssociationTypes []AssociationTypesItemModel `tfsdk:"association_types"`
+}
+
+func (d *AssociationTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_association_types"
+}
+
+func (d *AssociationTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Akeneo association types data source. Lists all association types available in Akeneo.",
+
+		Attributes: map[string]schema.Attribute{
+			"codes": schema.SetAttribute{
+				Description: "Codes of all association types",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"association_types": schema.ListNestedAttribute{
+				Description: "All association types",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							Description: "Association type code",
+							Computed:    true,
+						},
+						"labels": schema.MapAttribute{
+							Description: "Label definition per locale",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"is_quantified": schema.BoolAttribute{
+							Description: "Whether the association type is a quantified association",
+							Computed:    true,
+						},
+						"is_two_way": schema.BoolAttribute{
+							Description: "Whether the association type is a two-way association",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AssociationTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*DataSourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DataSourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	if data.Client == nil {
+		resp.Diagnostics.AddError(
+			"Missing client instance",
+			"Client instance pointer passed to Configure is required, got nil",
+		)
+		return
+	}
+
+	d.client = akeneox.NewAssociationTypeClient(data.Client)
+}
+
+func (d *AssociationTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AssociationTypesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData, err := d.client.ListAssociationTypes()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading association types",
+			"An unexpected error occurred when listing association types. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	data.AssociationTypes = make([]AssociationTypesItemModel, len(apiData))
+	codes := make([]attr.Value, len(apiData))
+	for i, v := range apiData {
+		data.AssociationTypes[i] = d.mapToTfObject(&resp.Diagnostics, v)
+		codes[i] = types.StringValue(v.Code)
+	}
+
+	setVal, diags := types.SetValue(types.StringType, codes)
+	resp.Diagnostics.Append(diags...)
+	data.Codes = setVal
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *AssociationTypesDataSource) mapToTfObject(respDiags *diag.Diagnostics, apiData akeneox.AssociationType) AssociationTypesItemModel {
+	return AssociationTypesItemModel{
+		Code:         types.StringValue(apiData.Code),
+		Labels:       typesx.MapValue(respDiags, apiData.Labels),
+		IsQuantified: types.BoolPointerValue(apiData.IsQuantified),
+		IsTwoWay:     types.BoolPointerValue(apiData.IsTwoWay),
+	}
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAssociationTypesDataSource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_association_type" "upsell" {
+  code = "upsell"
+}
+
+resource "akeneo_association_type" "pack" {
+  code          = "pack"
+  is_quantified = true
+}
+
+data "akeneo_association_types" "test" {
+  depends_on = [akeneo_association_type.upsell, akeneo_association_type.pack]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akeneo_association_types.test", "codes.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.akeneo_association_types.test", "codes.*", "pack"),
+					resource.TestCheckTypeSetElemAttr("data.akeneo_association_types.test", "codes.*", "upsell"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_association_type.upsell", "akeneo_association_type.pack"),
+			},
+		},
+	})
+}
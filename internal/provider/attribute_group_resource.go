@@ -7,11 +7,11 @@ import (
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -20,6 +20,7 @@ import (
 var _ resource.Resource = &AttributeGroupResource{}
 var _ resource.ResourceWithImportState = &AttributeGroupResource{}
 var _ resource.ResourceWithConfigure = &AttributeGroupResource{}
+var _ resource.ResourceWithModifyPlan = &AttributeGroupResource{}
 
 func NewAttributeGroupResource() resource.Resource {
 	return &AttributeGroupResource{}
@@ -28,13 +29,16 @@ func NewAttributeGroupResource() resource.Resource {
 // AttributeGroupResource defines the resource implementation.
 type AttributeGroupResource struct {
 	client *akeneox.AttributeService
+	labels labelDefaults
 }
 
 // AttributeGroupResourceModel describes the resource data model.
 type AttributeGroupResourceModel struct {
-	Code      types.String `tfsdk:"code"`
-	SortOrder types.Int64  `tfsdk:"sort_order"`
-	Labels    types.Map    `tfsdk:"labels"`
+	Code            types.String `tfsdk:"code"`
+	SortOrder       types.Int64  `tfsdk:"sort_order"`
+	Labels          types.Map    `tfsdk:"labels"`
+	Label           types.String `tfsdk:"label"`
+	EffectiveLabels types.Map    `tfsdk:"effective_labels"`
 }
 
 func (r *AttributeGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +57,8 @@ func (r *AttributeGroupResource) Schema(ctx context.Context, req resource.Schema
 			"sort_order": schema.Int64Attribute{
 				Description: "Order of the attribute group",
 				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
 				Validators: []validator.Int64{
 					int64validator.Between(0, 2147483647),
 				},
@@ -65,6 +71,8 @@ func (r *AttributeGroupResource) Schema(ctx context.Context, req resource.Schema
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
 		},
 	}
 }
@@ -93,9 +101,14 @@ func (r *AttributeGroupResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewAttributeClient(data.Client)
 }
 
+func (r *AttributeGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+}
+
 func (r *AttributeGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data AttributeGroupResourceModel
 
@@ -180,6 +193,8 @@ func (r *AttributeGroupResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
 	_, err := r.client.UpdateAttributeGroup(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -227,15 +242,7 @@ func (r *AttributeGroupResource) mapToApiObject(ctx context.Context, diags *diag
 		a.SortOrder = int(data.SortOrder.ValueInt64())
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
-	}
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
 
 	if diags.HasError() {
 		return nil
@@ -247,19 +254,7 @@ func (r *AttributeGroupResource) mapToApiObject(ctx context.Context, diags *diag
 func (r *AttributeGroupResource) mapToTfObject(respDiags *diag.Diagnostics, data *AttributeGroupResourceModel, attrData *akeneox.AttributeGroup) {
 	data.Code = types.StringValue(attrData.Code)
 
-	if attrData.Labels != nil {
-		elements := make(map[string]attr.Value, len(attrData.Labels))
-
-		for k, v := range attrData.Labels {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, attrData.Labels)
 
 	data.SortOrder = types.Int64Value(int64(attrData.SortOrder))
 }
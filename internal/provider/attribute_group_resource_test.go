@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAttributeGroupResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_attribute_group" "test" {
+  code       = "marketing"
+  sort_order = 1
+  labels     = { en_US = "Marketing" }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "code", "marketing"),
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "sort_order", "1"),
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "labels.en_US", "Marketing"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_attribute_group.test",
+				ImportState:                          true,
+				ImportStateId:                        "marketing",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_attribute_group" "test" {
+  code       = "marketing"
+  sort_order = 2
+  labels     = { de_DE = "Marketing" }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "sort_order", "2"),
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "labels.de_DE", "Marketing"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute_group.test"),
+			},
+		},
+	})
+}
+
+func TestAccAttributeGroupResource_defaultLabelLocales(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfigWithArguments(server, `default_label_locales = ["en_US", "de_DE"]`, `
+resource "akeneo_attribute_group" "test" {
+  code   = "marketing"
+  label  = "Marketing"
+  labels = { de_DE = "Werbung" }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "effective_labels.%", "2"),
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "effective_labels.en_US", "Marketing"),
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "effective_labels.de_DE", "Werbung"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute_group.test"),
+			},
+		},
+	})
+}
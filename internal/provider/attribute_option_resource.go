@@ -8,7 +8,6 @@ import (
 	goakeneo "github.com/ezifyio/go-akeneo"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +20,7 @@ import (
 var _ resource.Resource = &AttributeOptionResource{}
 var _ resource.ResourceWithImportState = &AttributeOptionResource{}
 var _ resource.ResourceWithConfigure = &AttributeOptionResource{}
+var _ resource.ResourceWithModifyPlan = &AttributeOptionResource{}
 
 func NewAttributeOptionResource() resource.Resource {
 	return &AttributeOptionResource{}
@@ -29,14 +29,17 @@ func NewAttributeOptionResource() resource.Resource {
 // AttributeOptionResource defines the resource implementation.
 type AttributeOptionResource struct {
 	client *akeneox.AttributeService
+	labels labelDefaults
 }
 
 // AttributeOptionResourceModel describes the resource data model.
 type AttributeOptionResourceModel struct {
-	Code      types.String `tfsdk:"code"`
-	Attribute types.String `tfsdk:"attribute"`
-	SortOrder types.Int64  `tfsdk:"sort_order"`
-	Labels    types.Map    `tfsdk:"labels"`
+	Code            types.String `tfsdk:"code"`
+	Attribute       types.String `tfsdk:"attribute"`
+	SortOrder       types.Int64  `tfsdk:"sort_order"`
+	Labels          types.Map    `tfsdk:"labels"`
+	Label           types.String `tfsdk:"label"`
+	EffectiveLabels types.Map    `tfsdk:"effective_labels"`
 }
 
 func (r *AttributeOptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,6 +74,8 @@ func (r *AttributeOptionResource) Schema(ctx context.Context, req resource.Schem
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
 		},
 	}
 }
@@ -99,9 +104,14 @@ func (r *AttributeOptionResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewAttributeClient(data.Client)
 }
 
+func (r *AttributeOptionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+}
+
 func (r *AttributeOptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data AttributeOptionResourceModel
 
@@ -186,6 +196,8 @@ func (r *AttributeOptionResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
 	_, err := r.client.UpdateAttributeOption(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -235,15 +247,7 @@ func (r *AttributeOptionResource) mapToApiObject(ctx context.Context, diags *dia
 		a.SortOrder = &v
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
-	}
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
 
 	if diags.HasError() {
 		return nil
@@ -256,19 +260,7 @@ func (r *AttributeOptionResource) mapToTfObject(respDiags *diag.Diagnostics, dat
 	data.Code = types.StringValue(attrData.Code)
 	data.Attribute = types.StringValue(attrData.Attribute)
 
-	if attrData.Labels != nil {
-		elements := make(map[string]attr.Value, len(attrData.Labels))
-
-		for k, v := range attrData.Labels {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, attrData.Labels)
 
 	if attrData.SortOrder != nil {
 		data.SortOrder = types.Int64Value(int64(*attrData.SortOrder))
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccAttributeOptionAttributeConfig = `
+resource "akeneo_attribute" "color" {
+  code  = "color"
+  type  = "pim_catalog_simpleselect"
+  group = "other"
+}
+`
+
+func TestAccAttributeOptionResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccAttributeOptionAttributeConfig+`
+resource "akeneo_attribute_option" "test" {
+  code       = "red"
+  attribute  = akeneo_attribute.color.code
+  sort_order = 1
+  labels     = { en_US = "Red" }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_option.test", "code", "red"),
+					resource.TestCheckResourceAttr("akeneo_attribute_option.test", "attribute", "color"),
+					resource.TestCheckResourceAttr("akeneo_attribute_option.test", "sort_order", "1"),
+					resource.TestCheckResourceAttr("akeneo_attribute_option.test", "labels.en_US", "Red"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccAttributeOptionAttributeConfig+`
+resource "akeneo_attribute_option" "test" {
+  code       = "red"
+  attribute  = akeneo_attribute.color.code
+  sort_order = 2
+  labels     = { en_US = "Red", fr_FR = "Rouge" }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_option.test", "sort_order", "2"),
+					resource.TestCheckResourceAttr("akeneo_attribute_option.test", "labels.fr_FR", "Rouge"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute.color", "akeneo_attribute_option.test"),
+			},
+		},
+	})
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const optionLabelColumnPrefix = "label-"
+
+// readAttributeOptionsFile parses a CSV or JSON attribute options file, with
+// the columns code, sort_order and label-<locale>, and returns the options
+// together with the SHA256 hash of the file content.
+//
+// CSV files are separated by a comma or by a semicolon as used by Akeneo
+// exports. JSON files contain an array of objects with the same keys.
+func readAttributeOptionsFile(filePath string) (map[string]AttributeOptionsItemModel, string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	var rows []map[string]string
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv":
+		rows, err = parseAttributeOptionsCSV(content)
+	case ".json":
+		rows, err = parseAttributeOptionsJSON(content)
+	default:
+		err = errors.New("unsupported file extension, expected .csv or .json")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	options := make(map[string]AttributeOptionsItemModel, len(rows))
+	for i, row := range rows {
+		code, item, err := attributeOptionFromRow(row)
+		if err != nil {
+			return nil, "", fmt.Errorf("option %d: %w", i+1, err)
+		}
+		if _, ok := options[code]; ok {
+			return nil, "", fmt.Errorf("option %d: duplicate option code %s", i+1, code)
+		}
+		options[code] = item
+	}
+
+	return options, hash, nil
+}
+
+func parseAttributeOptionsCSV(content []byte) ([]map[string]string, error) {
+	// Spreadsheet exports often start with a UTF-8 byte order mark.
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	r := csv.NewReader(bytes.NewReader(content))
+	if header, _, _ := bytes.Cut(content, []byte("\n")); bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("missing header row")
+	}
+
+	header := records[0]
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+	}
+	if !slices.Contains(header, "code") {
+		return nil, errors.New("missing code column")
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseAttributeOptionsJSON(content []byte) ([]map[string]string, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(content, &items); err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]string, len(items))
+	for i, item := range items {
+		row := make(map[string]string, len(item))
+		for key, value := range item {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				row[key] = v
+			case float64:
+				row[key] = strconv.FormatFloat(v, 'f', -1, 64)
+			default:
+				return nil, fmt.Errorf("option %d: unsupported value of %s", i+1, key)
+			}
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+func attributeOptionFromRow(row map[string]string) (string, AttributeOptionsItemModel, error) {
+	item := AttributeOptionsItemModel{
+		SortOrder: types.Int64Null(),
+		Labels:    types.MapNull(types.StringType),
+	}
+
+	code := strings.TrimSpace(row["code"])
+	if code == "" {
+		return "", item, errors.New("missing code")
+	}
+
+	if v := strings.TrimSpace(row["sort_order"]); v != "" {
+		sortOrder, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || sortOrder < 0 {
+			return "", item, fmt.Errorf("invalid sort_order %q of option %s", v, code)
+		}
+		item.SortOrder = types.Int64Value(sortOrder)
+	}
+
+	labels := make(map[string]string)
+	for column, value := range row {
+		locale, ok := strings.CutPrefix(column, optionLabelColumnPrefix)
+		if !ok || value == "" {
+			continue
+		}
+		if !stringvalidatorx.IsValidLocaleCode(locale) {
+			return "", item, fmt.Errorf("invalid locale %q in column %s", locale, column)
+		}
+		labels[locale] = value
+	}
+
+	var diags diag.Diagnostics
+	item.Labels = typesx.MapValue(&diags, labels)
+	if diags.HasError() {
+		return "", item, fmt.Errorf("invalid labels of option %s", code)
+	}
+
+	return code, item, nil
+}
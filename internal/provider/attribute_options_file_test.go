@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseAttributeOptionsCSV(t *testing.T) {
+	cases := []struct {
+		name      string
+		content   string
+		expected  []map[string]string
+		expectErr string
+	}{
+		{
+			name:    "comma separated",
+			content: "code,sort_order,label-en_US\nred,1,Red\nblue,2,Blue\n",
+			expected: []map[string]string{
+				{"code": "red", "sort_order": "1", "label-en_US": "Red"},
+				{"code": "blue", "sort_order": "2", "label-en_US": "Blue"},
+			},
+		},
+		{
+			name:    "semicolon separated",
+			content: "code;sort_order;label-en_US\nred;1;Red, dark\n",
+			expected: []map[string]string{
+				{"code": "red", "sort_order": "1", "label-en_US": "Red, dark"},
+			},
+		},
+		{
+			name:    "byte order mark",
+			content: "\xef\xbb\xbfcode,label-en_US\nred,Red\n",
+			expected: []map[string]string{
+				{"code": "red", "label-en_US": "Red"},
+			},
+		},
+		{
+			name:    "header with spaces",
+			content: "code , label-en_US\nred,Red\n",
+			expected: []map[string]string{
+				{"code": "red", "label-en_US": "Red"},
+			},
+		},
+		{
+			name:     "header only",
+			content:  "code,label-en_US\n",
+			expected: []map[string]string{},
+		},
+		{
+			name:      "empty file",
+			content:   "",
+			expectErr: "missing header row",
+		},
+		{
+			name:      "missing code column",
+			content:   "sort_order,label-en_US\n1,Red\n",
+			expectErr: "missing code column",
+		},
+		{
+			name:      "wrong number of fields",
+			content:   "code,label-en_US\nred\n",
+			expectErr: "wrong number of fields",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rows, err := parseAttributeOptionsCSV([]byte(c.content))
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error %q, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(rows, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, rows)
+			}
+		})
+	}
+}
+
+func TestParseAttributeOptionsJSON(t *testing.T) {
+	cases := []struct {
+		name      string
+		content   string
+		expected  []map[string]string
+		expectErr string
+	}{
+		{
+			name:    "options",
+			content: `[{"code": "red", "sort_order": 1, "label-en_US": "Red"}, {"code": "blue", "sort_order": "2"}]`,
+			expected: []map[string]string{
+				{"code": "red", "sort_order": "1", "label-en_US": "Red"},
+				{"code": "blue", "sort_order": "2"},
+			},
+		},
+		{
+			name:    "null values",
+			content: `[{"code": "red", "sort_order": null}]`,
+			expected: []map[string]string{
+				{"code": "red"},
+			},
+		},
+		{
+			name:     "empty array",
+			content:  `[]`,
+			expected: []map[string]string{},
+		},
+		{
+			name:      "unsupported value",
+			content:   `[{"code": "red", "labels": {"en_US": "Red"}}]`,
+			expectErr: "option 1: unsupported value of labels",
+		},
+		{
+			name:      "invalid JSON",
+			content:   `[{"code": "red",}]`,
+			expectErr: "invalid character",
+		},
+		{
+			name:      "object instead of array",
+			content:   `{"code": "red"}`,
+			expectErr: "cannot unmarshal object",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rows, err := parseAttributeOptionsJSON([]byte(c.content))
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error %q, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(rows, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, rows)
+			}
+		})
+	}
+}
+
+func TestReadAttributeOptionsFile(t *testing.T) {
+	labels := func(elements map[string]string) types.Map {
+		values := make(map[string]attr.Value, len(elements))
+		for k, v := range elements {
+			values[k] = types.StringValue(v)
+		}
+		return types.MapValueMust(types.StringType, values)
+	}
+
+	cases := []struct {
+		name      string
+		fileName  string
+		content   string
+		expected  map[string]AttributeOptionsItemModel
+		expectErr string
+	}{
+		{
+			name:     "csv",
+			fileName: "options.csv",
+			content:  "code,sort_order,label-en_US,label-fr_FR\nred,1,Red,Rouge\nblue,,Blue,\n",
+			expected: map[string]AttributeOptionsItemModel{
+				"red":  {SortOrder: types.Int64Value(1), Labels: labels(map[string]string{"en_US": "Red", "fr_FR": "Rouge"})},
+				"blue": {SortOrder: types.Int64Null(), Labels: labels(map[string]string{"en_US": "Blue"})},
+			},
+		},
+		{
+			name:     "json",
+			fileName: "options.JSON",
+			content:  `[{"code": "red", "sort_order": 1}]`,
+			expected: map[string]AttributeOptionsItemModel{
+				"red": {SortOrder: types.Int64Value(1), Labels: types.MapNull(types.StringType)},
+			},
+		},
+		{
+			name:      "duplicate codes",
+			fileName:  "options.csv",
+			content:   "code\nred\nblue\nred\n",
+			expectErr: "option 3: duplicate option code red",
+		},
+		{
+			name:      "missing code",
+			fileName:  "options.json",
+			content:   `[{"code": "red"}, {"sort_order": 2}]`,
+			expectErr: "option 2: missing code",
+		},
+		{
+			name:      "invalid sort order",
+			fileName:  "options.csv",
+			content:   "code,sort_order\nred,-1\n",
+			expectErr: `option 1: invalid sort_order "-1" of option red`,
+		},
+		{
+			name:      "invalid locale",
+			fileName:  "options.csv",
+			content:   "code,label-english\nred,Red\n",
+			expectErr: `option 1: invalid locale "english" in column label-english`,
+		},
+		{
+			name:      "invalid JSON",
+			fileName:  "options.json",
+			content:   `[`,
+			expectErr: "unexpected end of JSON input",
+		},
+		{
+			name:      "unsupported extension",
+			fileName:  "options.xlsx",
+			content:   "code\nred\n",
+			expectErr: "unsupported file extension",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), c.fileName)
+			if err := os.WriteFile(filePath, []byte(c.content), 0o600); err != nil {
+				t.Fatalf("unable to write file: %v", err)
+			}
+
+			options, hash, err := readAttributeOptionsFile(filePath)
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error %q, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(hash) != 64 {
+				t.Errorf("expected a SHA256 hash, got %s", hash)
+			}
+			if len(options) != len(c.expected) {
+				t.Fatalf("expected %d options, got %d", len(c.expected), len(options))
+			}
+			for code, expected := range c.expected {
+				option, ok := options[code]
+				if !ok {
+					t.Errorf("expected option %s", code)
+					continue
+				}
+				if !option.SortOrder.Equal(expected.SortOrder) || !option.Labels.Equal(expected.Labels) {
+					t.Errorf("expected option %s to be %v, got %v", code, expected, option)
+				}
+			}
+		})
+	}
+
+	if _, _, err := readAttributeOptionsFile(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
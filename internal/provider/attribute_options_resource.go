@@ -0,0 +1,421 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AttributeOptionsResource{}
+var _ resource.ResourceWithImportState = &AttributeOptionsResource{}
+var _ resource.ResourceWithConfigure = &AttributeOptionsResource{}
+var _ resource.ResourceWithModifyPlan = &AttributeOptionsResource{}
+
+func NewAttributeOptionsResource() resource.Resource {
+	return &AttributeOptionsResource{}
+}
+
+// AttributeOptionsResource defines the resource implementation.
+type AttributeOptionsResource struct {
+	client *akeneox.AttributeService
+}
+
+type AttributeOptionsItemModel struct {
+	SortOrder types.Int64 `tfsdk:"sort_order"`
+	Labels    types.Map   `tfsdk:"labels"`
+}
+
+// AttributeOptionsResourceModel describes the resource data model.
+type AttributeOptionsResourceModel struct {
+	Attribute        types.String                         `tfsdk:"attribute"`
+	Options          map[string]AttributeOptionsItemModel `tfsdk:"options"`
+	SourceFile       types.String                         `tfsdk:"source_file"`
+	SourceFileSha256 types.String                         `tfsdk:"source_file_sha256"`
+	AdoptExisting    types.Bool                           `tfsdk:"adopt_existing"`
+}
+
+func (r *AttributeOptionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attribute_options"
+}
+
+func (r *AttributeOptionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Akeneo attribute options resource. Manages the full option set of a single attribute, options not present in the configuration are shown as changes. Do not combine with `akeneo_attribute_option` resources of the same attribute.",
+
+		Attributes: map[string]schema.Attribute{
+			"attribute": schema.StringAttribute{
+				Description: "Parent attribute code",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"options": schema.MapNestedAttribute{
+				Description: "Attribute options keyed by the option code. Computed from source_file when it is set",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Map{
+					mapvalidator.ExactlyOneOf(path.MatchRoot("source_file")),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"sort_order": schema.Int64Attribute{
+							Description: "Order of the attribute option",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(0, 2147483647),
+							},
+						},
+						"labels": schema.MapAttribute{
+							Description: "Label definition per locale",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
+							},
+						},
+					},
+				},
+			},
+			"source_file": schema.StringAttribute{
+				Description: "Path to a local CSV or JSON file with the columns code, sort_order and label-<locale> to import the options from. " +
+					"CSV files may be separated by commas or semicolons, JSON files contain an array of objects with the same keys",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"source_file_sha256": schema.StringAttribute{
+				Description: "SHA256 hash of the source file content",
+				Computed:    true,
+			},
+			"adopt_existing": adoptExistingAttribute(),
+		},
+	}
+}
+
+func (r *AttributeOptionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	if data.Client == nil {
+		resp.Diagnostics.AddError(
+			"Missing client instance",
+			"Client instance pointer passed to Configure is required, got nil",
+		)
+		return
+	}
+
+	r.client = akeneox.NewAttributeClient(data.Client)
+}
+
+func (r *AttributeOptionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// The options are unknown until they are read from the source file, so
+	// the plan is read attribute by attribute.
+	var attribute, sourceFile types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("attribute"), &attribute)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source_file"), &sourceFile)...)
+
+	if resp.Diagnostics.HasError() || sourceFile.IsUnknown() {
+		return
+	}
+
+	// Options of a source file are planned from its content, so that file
+	// edits show as a diff of the options.
+	sourceFileSha256 := types.StringNull()
+	if !sourceFile.IsNull() {
+		options, hash, err := readAttributeOptionsFile(sourceFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source_file"),
+				"Invalid attribute options file",
+				"An unexpected error occurred when reading attribute options from "+sourceFile.ValueString()+". \n\n"+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("options"), options)...)
+		sourceFileSha256 = types.StringValue(hash)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_file_sha256"), sourceFileSha256)...)
+
+	// Options can only be removed by an update.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planned, prior types.Map
+
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("options"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("options"), &prior)...)
+
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+
+	removed := make([]string, 0)
+	for code := range prior.Elements() {
+		if _, ok := planned.Elements()[code]; !ok {
+			removed = append(removed, code)
+		}
+	}
+
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("options"),
+			"Attribute options can not be removed",
+			"The Akeneo API does not support deletes for attribute options. "+
+				"Add the following options of attribute "+attribute.ValueString()+" to the configuration or remove them in Akeneo: "+
+				strings.Join(removed, ", "),
+		)
+	}
+}
+
+func (r *AttributeOptionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AttributeOptionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The options of an attribute are created by the same PATCH request
+	// which updates them, so existing options have to be checked first.
+	existing, err := r.client.ListAttributeOptions(data.Attribute.ValueString())
+	if err != nil && !akeneox.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error while creating attribute options",
+			"An unexpected error occurred when reading existing attribute options. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+	if len(existing) > 0 && !data.AdoptExisting.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attribute"),
+			"The attribute options already exist",
+			"The attribute "+data.Attribute.ValueString()+" already has options in Akeneo. Import them into the state to manage them, "+
+				"or set adopt_existing = true to take them over on create.",
+		)
+		return
+	}
+
+	r.updateOptions(ctx, &resp.Diagnostics, &data, nil)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AttributeOptionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AttributeOptionsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData, err := r.client.ListAttributeOptions(data.Attribute.ValueString())
+	if err != nil {
+		if akeneox.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error while reading attribute options",
+			"An unexpected error occurred when reading attribute options. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	r.mapToTfObject(&resp.Diagnostics, &data, apiData)
+	data.AdoptExisting = adoptExistingValue(data.AdoptExisting)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AttributeOptionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AttributeOptionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.updateOptions(ctx, &resp.Diagnostics, &data, state.Options)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AttributeOptionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddError(
+		"This resource does not support deletes",
+		"This resource does not support deletes. The Akeneo API does not support deletes for attribute options.",
+	)
+}
+
+func (r *AttributeOptionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("attribute"), req, resp)
+}
+
+// updateOptions sends all options in a single collection patch and maps the
+// status of every option to its diagnostics. The prior options are those of
+// the state, nil on create.
+func (r *AttributeOptionsResource) updateOptions(ctx context.Context, diags *diag.Diagnostics, data *AttributeOptionsResourceModel, prior map[string]AttributeOptionsItemModel) {
+	options := r.mapToApiObject(ctx, diags, data, prior)
+
+	if diags.HasError() || len(options) == 0 {
+		return
+	}
+
+	lines, err := r.client.UpdateAttributeOptions(data.Attribute.ValueString(), options)
+	if err != nil {
+		diags.AddError(
+			"Error while updating attribute options",
+			"An unexpected error occurred when updating attribute options. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	for _, line := range lines {
+		if !line.IsError() {
+			continue
+		}
+
+		apiErr := &akeneox.Error{
+			StatusCode: line.StatusCode,
+			Message:    line.Message,
+			Errors:     line.Errors,
+		}
+		diags.AddAttributeError(
+			path.Root("options").AtMapKey(line.Code),
+			"Error while updating an attribute option",
+			"An unexpected error occurred when updating attribute option "+line.Code+". \n\n"+
+				"Akeneo API Error: "+apiErr.Error(),
+		)
+	}
+}
+
+func (r *AttributeOptionsResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *AttributeOptionsResourceModel, prior map[string]AttributeOptionsItemModel) []goakeneo.AttributeOption {
+	codes := make([]string, 0, len(data.Options))
+	for code := range data.Options {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	options := make([]goakeneo.AttributeOption, len(codes))
+	for i, code := range codes {
+		v := data.Options[code]
+		a := goakeneo.AttributeOption{
+			Code:      code,
+			Attribute: data.Attribute.ValueString(),
+			Labels:    typesx.MapElements[string](ctx, diags, v.Labels),
+		}
+
+		// The collection patch merges the labels, a removed label is only
+		// removed by Akeneo when it is sent empty.
+		for locale := range prior[code].Labels.Elements() {
+			if _, ok := a.Labels[locale]; !ok {
+				if a.Labels == nil {
+					a.Labels = make(map[string]string)
+				}
+				a.Labels[locale] = ""
+			}
+		}
+
+		if !(v.SortOrder.IsNull() || v.SortOrder.IsUnknown()) {
+			sortOrder := int(v.SortOrder.ValueInt64())
+			a.SortOrder = &sortOrder
+		}
+
+		options[i] = a
+	}
+
+	if diags.HasError() {
+		return nil
+	}
+
+	return options
+}
+
+func (r *AttributeOptionsResource) mapToTfObject(respDiags *diag.Diagnostics, data *AttributeOptionsResourceModel, apiData []goakeneo.AttributeOption) {
+	options := make(map[string]AttributeOptionsItemModel, len(apiData))
+
+	for _, v := range apiData {
+		item := AttributeOptionsItemModel{
+			SortOrder: types.Int64Null(),
+			Labels:    typesx.MapValue(respDiags, v.Labels),
+		}
+
+		// Keep an unmanaged sort order unset, options added outside of
+		// Terraform always show their sort order.
+		prior, managed := data.Options[v.Code]
+		if v.SortOrder != nil && !(managed && prior.SortOrder.IsNull()) {
+			item.SortOrder = types.Int64Value(int64(*v.SortOrder))
+		}
+
+		options[v.Code] = item
+	}
+
+	data.Options = options
+}
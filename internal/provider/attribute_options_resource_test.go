@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAttributeOptionsResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccAttributeOptionAttributeConfig+`
+resource "akeneo_attribute_options" "test" {
+  attribute = akeneo_attribute.color.code
+  options = {
+    red  = { sort_order = 1, labels = { en_US = "Red" } }
+    blue = { sort_order = 2, labels = { en_US = "Blue" } }
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_options.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("akeneo_attribute_options.test", "options.red.labels.en_US", "Red"),
+					resource.TestCheckResourceAttr("akeneo_attribute_options.test", "options.blue.sort_order", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_attribute_options.test",
+				ImportState:                          true,
+				ImportStateId:                        "color",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "attribute",
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccAttributeOptionAttributeConfig+`
+resource "akeneo_attribute_options" "test" {
+  attribute = akeneo_attribute.color.code
+  options = {
+    red  = { sort_order = 1, labels = { en_US = "Red", fr_FR = "Rouge" } }
+    blue = { sort_order = 2, labels = { en_US = "Blue" } }
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_options.test", "options.red.labels.fr_FR", "Rouge"),
+				),
+			},
+			// Removed labels are removed in Akeneo
+			{
+				Config: testAccProviderConfig(server, testAccAttributeOptionAttributeConfig+`
+resource "akeneo_attribute_options" "test" {
+  attribute = akeneo_attribute.color.code
+  options = {
+    red  = { sort_order = 1, labels = { fr_FR = "Rouge" } }
+    blue = { sort_order = 2 }
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_options.test", "options.red.labels.%", "1"),
+					resource.TestCheckNoResourceAttr("akeneo_attribute_options.test", "options.red.labels.en_US"),
+					resource.TestCheckNoResourceAttr("akeneo_attribute_options.test", "options.blue.labels.%"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute.color", "akeneo_attribute_options.test"),
+			},
+		},
+	})
+}
+
+func TestAccAttributeOptionsResource_sourceFile(t *testing.T) {
+	server := testAccServer(t)
+
+	sourceFile := filepath.Join(t.TempDir(), "colors.csv")
+	if err := os.WriteFile(sourceFile, []byte("code;sort_order;label-en_US\nred;1;Red\nblue;2;Blue\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server, testAccAttributeOptionAttributeConfig+`
+resource "akeneo_attribute_options" "test" {
+  attribute   = akeneo_attribute.color.code
+  source_file = "`+filepath.ToSlash(sourceFile)+`"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_options.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("akeneo_attribute_options.test", "options.blue.labels.en_US", "Blue"),
+					resource.TestCheckResourceAttrSet("akeneo_attribute_options.test", "source_file_sha256"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute.color", "akeneo_attribute_options.test"),
+			},
+		},
+	})
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 	"fmt"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	goakeneo "github.com/ezifyio/go-akeneo"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"math/big"
@@ -25,6 +29,8 @@ import (
 var _ resource.Resource = &AttributeResource{}
 var _ resource.ResourceWithImportState = &AttributeResource{}
 var _ resource.ResourceWithConfigure = &AttributeResource{}
+var _ resource.ResourceWithModifyPlan = &AttributeResource{}
+var _ resource.ResourceWithUpgradeState = &AttributeResource{}
 
 func NewAttributeResource() resource.Resource {
 	return &AttributeResource{}
@@ -33,6 +39,7 @@ func NewAttributeResource() resource.Resource {
 // AttributeResource defines the resource implementation.
 type AttributeResource struct {
 	client *akeneox.AttributeService
+	labels labelDefaults
 }
 
 // AttributeResourceModel describes the resource data model.
@@ -40,12 +47,14 @@ type AttributeResourceModel struct {
 	Code                types.String `tfsdk:"code"`
 	Type                types.String `tfsdk:"type"`
 	Labels              types.Map    `tfsdk:"labels"`
+	Label               types.String `tfsdk:"label"`
+	EffectiveLabels     types.Map    `tfsdk:"effective_labels"`
 	Group               types.String `tfsdk:"group"`
 	GroupLabels         types.Map    `tfsdk:"group_labels"`
 	SortOrder           types.Int64  `tfsdk:"sort_order"`
 	Localizable         types.Bool   `tfsdk:"localizable"`
 	Scopable            types.Bool   `tfsdk:"scopable"`
-	AvailableLocales    types.List   `tfsdk:"available_locales"`
+	AvailableLocales    types.Set    `tfsdk:"available_locales"`
 	Unique              types.Bool   `tfsdk:"unique"`
 	UseableAsGridFilter types.Bool   `tfsdk:"useable_as_grid_filter"`
 	MaxCharacters       types.Int64  `tfsdk:"max_characters"`
@@ -60,7 +69,7 @@ type AttributeResourceModel struct {
 	DefaultMetricUnit   types.String `tfsdk:"default_metric_unit"`
 	DateMin             types.String `tfsdk:"date_min"`
 	DateMax             types.String `tfsdk:"date_max"`
-	AllowedExtensions   types.List   `tfsdk:"allowed_extensions"`
+	AllowedExtensions   types.Set    `tfsdk:"allowed_extensions"`
 	MaxFileSize         types.Int64  `tfsdk:"max_file_size"`
 	ReferenceDataName   types.String `tfsdk:"reference_data_name"`
 	DefaultValue        types.Bool   `tfsdk:"default_value"`
@@ -74,6 +83,7 @@ func (r *AttributeResource) Metadata(ctx context.Context, req resource.MetadataR
 func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Akeneo attribute resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"code": schema.StringAttribute{
@@ -98,13 +108,16 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
 			"group": schema.StringAttribute{
 				Description: "Attribute group",
 				Required:    true,
 			},
 			"group_labels": schema.MapAttribute{
-				Description: "Label definition per locale",
+				Description: "Labels of the attribute group per locale, read from the attribute group",
 				Optional:    true,
+				Computed:    true,
 				ElementType: types.StringType,
 				Validators: []validator.Map{
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
@@ -113,6 +126,8 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 			"sort_order": schema.Int64Attribute{
 				Description: "Order of the attribute in its group",
 				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
 				Validators: []validator.Int64{
 					int64validator.Between(0, 2147483647),
 				},
@@ -120,26 +135,34 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 			"localizable": schema.BoolAttribute{
 				Description: "Whether the attribute is localizable, i.e. can have one value by locale",
 				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
 			},
 			"scopable": schema.BoolAttribute{
 				Description: "Whether the attribute is scopable, i.e. can have one value by channel",
 				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
 			},
-			"available_locales": schema.ListAttribute{
+			"available_locales": schema.SetAttribute{
 				Description: "To make the attribute locale specific, specify here for which locales it is specific",
 				Optional:    true,
 				ElementType: types.StringType,
-				Validators: []validator.List{
-					listvalidator.ValueStringsAre(stringvalidatorx.IsLocaleCode()),
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
 			"unique": schema.BoolAttribute{
 				Description: " Whether two values for the attribute cannot be the same",
 				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
 			},
 			"useable_as_grid_filter": schema.BoolAttribute{
 				Description: "Whether the attribute can be used as a filter for the product grid in the PIM user interface",
 				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
 			},
 			"max_characters": schema.Int64Attribute{
 				Description: "Number maximum of characters allowed for the value of the attribute when the attribute type is `pim_catalog_text`, `pim_catalog_textarea` or `pim_catalog_identifier`",
@@ -159,6 +182,10 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 			"wysiwyg_enabled": schema.BoolAttribute{
 				Description: "Whether the WYSIWYG interface is shown when the attribute type is `pim_catalog_textarea`",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"number_min": schema.NumberAttribute{
 				Description: "Minimum integer value allowed when the attribute type is `pim_catalog_metric`, `pim_catalog_price` or `pim_catalog_number`",
@@ -171,10 +198,18 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 			"decimals_allowed": schema.BoolAttribute{
 				Description: "Whether decimals are allowed when the attribute type is `pim_catalog_metric`, `pim_catalog_price` or `pim_catalog_number`",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"negative_allowed": schema.BoolAttribute{
 				Description: "Whether negative values are allowed when the attribute type is `pim_catalog_metric` or `pim_catalog_number`",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"metric_family": schema.StringAttribute{
 				Description: "Metric family when the attribute type is `pim_catalog_metric`",
@@ -192,7 +227,7 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 				Description: "Maximum date allowed when the attribute type is `pim_catalog_date`",
 				Optional:    true,
 			},
-			"allowed_extensions": schema.ListAttribute{
+			"allowed_extensions": schema.SetAttribute{
 				Description: "Extensions allowed when the attribute type is `pim_catalog_file` or `pim_catalog_image`",
 				Optional:    true,
 				ElementType: types.StringType,
@@ -208,6 +243,10 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 			"default_value": schema.BoolAttribute{
 				Description: "Default value for a Yes/No attribute, applied when creating a new product or product model (only available since the 5.0)",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"table_configuration": schema.ListAttribute{
 				Description: "Configuration of the Table attribute (columns)",
@@ -218,6 +257,186 @@ func (r *AttributeResource) Schema(ctx context.Context, req resource.SchemaReque
 	}
 }
 
+func (r *AttributeResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored the available locales and allowed extensions as ordered lists.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"code": schema.StringAttribute{
+						Required: true,
+					},
+					"type": schema.StringAttribute{
+						Required: true,
+					},
+					"labels": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"group": schema.StringAttribute{
+						Required: true,
+					},
+					"group_labels": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"sort_order": schema.Int64Attribute{
+						Optional: true,
+					},
+					"localizable": schema.BoolAttribute{
+						Optional: true,
+					},
+					"scopable": schema.BoolAttribute{
+						Optional: true,
+					},
+					"available_locales": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"unique": schema.BoolAttribute{
+						Optional: true,
+					},
+					"useable_as_grid_filter": schema.BoolAttribute{
+						Optional: true,
+					},
+					"max_characters": schema.Int64Attribute{
+						Optional: true,
+					},
+					"validation_rule": schema.StringAttribute{
+						Optional: true,
+					},
+					"validation_regexp": schema.StringAttribute{
+						Optional: true,
+					},
+					"wysiwyg_enabled": schema.BoolAttribute{
+						Optional: true,
+					},
+					"number_min": schema.NumberAttribute{
+						Optional: true,
+					},
+					"number_max": schema.NumberAttribute{
+						Optional: true,
+					},
+					"decimals_allowed": schema.BoolAttribute{
+						Optional: true,
+					},
+					"negative_allowed": schema.BoolAttribute{
+						Optional: true,
+					},
+					"metric_family": schema.StringAttribute{
+						Optional: true,
+					},
+					"default_metric_unit": schema.StringAttribute{
+						Optional: true,
+					},
+					"date_min": schema.StringAttribute{
+						Optional: true,
+					},
+					"date_max": schema.StringAttribute{
+						Optional: true,
+					},
+					"allowed_extensions": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"max_file_size": schema.Int64Attribute{
+						Optional: true,
+					},
+					"reference_data_name": schema.StringAttribute{
+						Optional: true,
+					},
+					"default_value": schema.BoolAttribute{
+						Optional: true,
+					},
+					"table_configuration": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					Code                types.String `tfsdk:"code"`
+					Type                types.String `tfsdk:"type"`
+					Labels              types.Map    `tfsdk:"labels"`
+					Group               types.String `tfsdk:"group"`
+					GroupLabels         types.Map    `tfsdk:"group_labels"`
+					SortOrder           types.Int64  `tfsdk:"sort_order"`
+					Localizable         types.Bool   `tfsdk:"localizable"`
+					Scopable            types.Bool   `tfsdk:"scopable"`
+					AvailableLocales    types.List   `tfsdk:"available_locales"`
+					Unique              types.Bool   `tfsdk:"unique"`
+					UseableAsGridFilter types.Bool   `tfsdk:"useable_as_grid_filter"`
+					MaxCharacters       types.Int64  `tfsdk:"max_characters"`
+					ValidationRule      types.String `tfsdk:"validation_rule"`
+					ValidationRegexp    types.String `tfsdk:"validation_regexp"`
+					WysiwygEnabled      types.Bool   `tfsdk:"wysiwyg_enabled"`
+					NumberMin           types.Number `tfsdk:"number_min"`
+					NumberMax           types.Number `tfsdk:"number_max"`
+					DecimalsAllowed     types.Bool   `tfsdk:"decimals_allowed"`
+					NegativeAllowed     types.Bool   `tfsdk:"negative_allowed"`
+					MetricFamily        types.String `tfsdk:"metric_family"`
+					DefaultMetricUnit   types.String `tfsdk:"default_metric_unit"`
+					DateMin             types.String `tfsdk:"date_min"`
+					DateMax             types.String `tfsdk:"date_max"`
+					AllowedExtensions   types.List   `tfsdk:"allowed_extensions"`
+					MaxFileSize         types.Int64  `tfsdk:"max_file_size"`
+					ReferenceDataName   types.String `tfsdk:"reference_data_name"`
+					DefaultValue        types.Bool   `tfsdk:"default_value"`
+					TableConfiguration  types.List   `tfsdk:"table_configuration"`
+				}
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := AttributeResourceModel{
+					Code:                prior.Code,
+					Type:                prior.Type,
+					Labels:              prior.Labels,
+					Label:               types.StringNull(),
+					EffectiveLabels:     prior.Labels,
+					Group:               prior.Group,
+					GroupLabels:         prior.GroupLabels,
+					SortOrder:           prior.SortOrder,
+					Localizable:         prior.Localizable,
+					Scopable:            prior.Scopable,
+					Unique:              prior.Unique,
+					UseableAsGridFilter: prior.UseableAsGridFilter,
+					MaxCharacters:       prior.MaxCharacters,
+					ValidationRule:      prior.ValidationRule,
+					ValidationRegexp:    prior.ValidationRegexp,
+					WysiwygEnabled:      prior.WysiwygEnabled,
+					NumberMin:           prior.NumberMin,
+					NumberMax:           prior.NumberMax,
+					DecimalsAllowed:     prior.DecimalsAllowed,
+					NegativeAllowed:     prior.NegativeAllowed,
+					MetricFamily:        prior.MetricFamily,
+					DefaultMetricUnit:   prior.DefaultMetricUnit,
+					DateMin:             prior.DateMin,
+					DateMax:             prior.DateMax,
+					MaxFileSize:         prior.MaxFileSize,
+					ReferenceDataName:   prior.ReferenceDataName,
+					DefaultValue:        prior.DefaultValue,
+					TableConfiguration:  prior.TableConfiguration,
+				}
+
+				availableLocales, diags := stringListToSet(prior.AvailableLocales)
+				resp.Diagnostics.Append(diags...)
+				data.AvailableLocales = availableLocales
+
+				allowedExtensions, diags := stringListToSet(prior.AllowedExtensions)
+				resp.Diagnostics.Append(diags...)
+				data.AllowedExtensions = allowedExtensions
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 func (r *AttributeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -242,9 +461,30 @@ func (r *AttributeResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewAttributeClient(data.Client)
 }
 
+func (r *AttributeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Akeneo returns the type dependent flags as null for the other attribute
+	// types. UseStateForUnknown does not keep a null state, so the flags which
+	// are not configured are planned with their state here.
+	for _, name := range []string{"wysiwyg_enabled", "decimals_allowed", "negative_allowed", "default_value"} {
+		var config, state types.Bool
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &config)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &state)...)
+		if config.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), state)...)
+		}
+	}
+}
+
 func (r *AttributeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data AttributeResourceModel
 
@@ -271,10 +511,19 @@ func (r *AttributeResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	if resp.Diagnostics.HasError() {
+	// Read the attribute back for the values Akeneo sets depending on the attribute type.
+	attrData, err := r.client.GetAttribute(data.Code.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading an attribute",
+			"An unexpected error occurred when reading created attribute. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
 		return
 	}
 
+	r.mapComputedValues(&resp.Diagnostics, &data, attrData)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -289,7 +538,7 @@ func (r *AttributeResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	attrData, err := r.client.GetAttribute(data.Code.ValueString(), nil)
+	attrData, err := r.client.GetAttribute(data.Code.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while reading an attribute",
@@ -329,6 +578,8 @@ func (r *AttributeResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
 	_, err := r.client.UpdateAttribute(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -339,10 +590,19 @@ func (r *AttributeResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	if resp.Diagnostics.HasError() {
+	// Read the attribute back for the values Akeneo sets depending on the attribute type.
+	attrData, err := r.client.GetAttribute(data.Code.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading an attribute",
+			"An unexpected error occurred when reading updated attribute. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
 		return
 	}
 
+	r.mapComputedValues(&resp.Diagnostics, &data, attrData)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -476,55 +736,15 @@ func (r *AttributeResource) mapToApiObject(ctx context.Context, diags *diag.Diag
 		a.DefaultValue = &v
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
-	}
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
 
-	if !(data.GroupLabels.IsNull() || data.GroupLabels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.GroupLabels.Elements()))
-		diags.Append(data.GroupLabels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.GroupLabels = labels
-	}
+	a.GroupLabels = typesx.MapElements[string](ctx, diags, data.GroupLabels)
 
-	if !(data.AvailableLocales.IsNull() || data.AvailableLocales.IsUnknown()) {
-		elements := make([]types.String, 0, len(data.AvailableLocales.Elements()))
-		diags.Append(data.AvailableLocales.ElementsAs(ctx, &elements, false)...)
-		locales := make([]string, len(elements))
-		for i, locale := range elements {
-			locales[i] = locale.ValueString()
-		}
-		a.AvailableLocales = locales
-	}
+	a.AvailableLocales = typesx.SetElements[string](ctx, diags, data.AvailableLocales)
 
-	if !(data.AllowedExtensions.IsNull() || data.AllowedExtensions.IsUnknown()) {
-		elements := make([]types.String, 0, len(data.AllowedExtensions.Elements()))
-		diags.Append(data.AllowedExtensions.ElementsAs(ctx, &elements, false)...)
-		exts := make([]string, len(elements))
-		for i, ext := range elements {
-			exts[i] = ext.ValueString()
-		}
-		a.AllowedExtensions = exts
-	}
+	a.AllowedExtensions = typesx.SetElements[string](ctx, diags, data.AllowedExtensions)
 
-	if !(data.TableConfiguration.IsNull() || data.TableConfiguration.IsUnknown()) {
-		elements := make([]types.String, 0, len(data.TableConfiguration.Elements()))
-		diags.Append(data.TableConfiguration.ElementsAs(ctx, &elements, false)...)
-		cfg := make([]string, len(elements))
-		for i, val := range elements {
-			cfg[i] = val.ValueString()
-		}
-		a.TableConfiguration = cfg
-	}
+	a.TableConfiguration = typesx.ListElements[string](ctx, diags, data.TableConfiguration)
 
 	if diags.HasError() {
 		return nil
@@ -533,38 +753,35 @@ func (r *AttributeResource) mapToApiObject(ctx context.Context, diags *diag.Diag
 	return &a
 }
 
+// mapComputedValues sets the values which were not configured and depend on the
+// attribute type, e.g. wysiwyg_enabled is only returned for textarea attributes,
+// and the labels of the attribute group.
+func (r *AttributeResource) mapComputedValues(respDiags *diag.Diagnostics, data *AttributeResourceModel, attrData *goakeneo.Attribute) {
+	if data.GroupLabels.IsUnknown() {
+		data.GroupLabels = typesx.MapValue(respDiags, attrData.GroupLabels)
+	}
+	if data.WysiwygEnabled.IsUnknown() {
+		data.WysiwygEnabled = types.BoolPointerValue(attrData.WysiwygEnabled)
+	}
+	if data.DecimalsAllowed.IsUnknown() {
+		data.DecimalsAllowed = types.BoolPointerValue(attrData.DecimalsAllowed)
+	}
+	if data.NegativeAllowed.IsUnknown() {
+		data.NegativeAllowed = types.BoolPointerValue(attrData.NegativeAllowed)
+	}
+	if data.DefaultValue.IsUnknown() {
+		data.DefaultValue = types.BoolPointerValue(attrData.DefaultValue)
+	}
+}
+
 func (r *AttributeResource) mapToTfObject(respDiags *diag.Diagnostics, data *AttributeResourceModel, attrData *goakeneo.Attribute) {
 	data.Code = types.StringValue(attrData.Code)
 	data.Type = types.StringValue(attrData.Type)
 	data.Group = types.StringValue(attrData.Group)
 
-	if len(attrData.Labels) > 0 {
-		elements := make(map[string]attr.Value, len(attrData.Labels))
-
-		for k, v := range attrData.Labels {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, attrData.Labels)
 
-	if len(attrData.GroupLabels) > 0 {
-		elements := make(map[string]attr.Value, len(attrData.GroupLabels))
-
-		for k, v := range attrData.GroupLabels {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.GroupLabels = mapVal
-	}
+	data.GroupLabels = typesx.MapValue(respDiags, attrData.GroupLabels)
 	if attrData.SortOrder != nil {
 		data.SortOrder = types.Int64Value(int64(*attrData.SortOrder))
 	}
@@ -574,19 +791,7 @@ func (r *AttributeResource) mapToTfObject(respDiags *diag.Diagnostics, data *Att
 	if attrData.Scopable != nil {
 		data.Scopable = types.BoolValue(*attrData.Scopable)
 	}
-	if len(attrData.AvailableLocales) > 0 {
-		elements := make([]attr.Value, len(attrData.AvailableLocales))
-
-		for k, v := range attrData.AvailableLocales {
-			elements[k] = types.StringValue(v)
-		}
-
-		listVal, diags := types.ListValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.AvailableLocales = listVal
-	}
+	data.AvailableLocales = typesx.SetValue(respDiags, attrData.AvailableLocales)
 	if attrData.Unique != nil {
 		data.Unique = types.BoolValue(*attrData.Unique)
 	}
@@ -639,25 +844,13 @@ func (r *AttributeResource) mapToTfObject(respDiags *diag.Diagnostics, data *Att
 	if attrData.DateMax != nil {
 		data.DateMax = types.StringValue(*attrData.DateMax)
 	}
-	if attrData.AvailableLocales != nil {
-		elements := make([]attr.Value, len(attrData.AllowedExtensions))
-
-		for k, v := range attrData.AllowedExtensions {
-			elements[k] = types.StringValue(v)
-		}
-
-		listVal, diags := types.ListValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.AllowedExtensions = listVal
-	}
+	data.AllowedExtensions = typesx.SetValue(respDiags, attrData.AllowedExtensions)
 	if attrData.MaxFileSize != nil {
 		v, err := strconv.ParseInt(*attrData.MaxFileSize, 10, 64)
 		if err != nil {
-			respDiags.AddError("Error parsing float value", "Error parsing float value. \n\n"+"Error: "+err.Error())
+			respDiags.AddError("Error parsing integer value", "Error parsing integer value. \n\n"+"Error: "+err.Error())
 		}
-		data.MaxCharacters = types.Int64Value(v)
+		data.MaxFileSize = types.Int64Value(v)
 	}
 	if attrData.ReferenceDataName != nil {
 		data.ReferenceDataName = types.StringValue(*attrData.ReferenceDataName)
@@ -665,17 +858,5 @@ func (r *AttributeResource) mapToTfObject(respDiags *diag.Diagnostics, data *Att
 	if attrData.DefaultValue != nil {
 		data.DefaultValue = types.BoolValue(*attrData.DefaultValue)
 	}
-	if attrData.TableConfiguration != nil {
-		elements := make([]attr.Value, len(attrData.TableConfiguration))
-
-		for k, v := range attrData.TableConfiguration {
-			elements[k] = types.StringValue(v)
-		}
-
-		listVal, diags := types.ListValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.TableConfiguration = listVal
-	}
+	data.TableConfiguration = typesx.ListValue(respDiags, attrData.TableConfiguration)
 }
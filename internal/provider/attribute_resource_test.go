@@ -1,48 +1,133 @@
 package provider
 
-/*
-func TestAccExampleResource(t *testing.T) {
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAttributeResource(t *testing.T) {
+	server := testAccServer(t)
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccProviderConfig(server, `
+resource "akeneo_attribute" "test" {
+  code   = "name"
+  type   = "pim_catalog_text"
+  group  = "other"
+  labels = { en_US = "Name" }
+
+  localizable    = true
+  max_characters = 100
+}
+`),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("akeneo_example.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("akeneo_example.test", "defaulted", "example value when not configured"),
-					resource.TestCheckResourceAttr("akeneo_example.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "code", "name"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "type", "pim_catalog_text"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "labels.en_US", "Name"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "localizable", "true"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "scopable", "false"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "max_characters", "100"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "akeneo_example.test",
-				ImportState:       true,
-				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute", "defaulted"},
+				ResourceName:                         "akeneo_attribute.test",
+				ImportState:                          true,
+				ImportStateId:                        "name",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+				ImportStateVerifyIgnore:              []string{"group_labels"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccProviderConfig(server, `
+resource "akeneo_attribute" "test" {
+  code   = "name"
+  type   = "pim_catalog_text"
+  group  = "other"
+  labels = { en_US = "Product name", de_DE = "Produktname" }
+
+  localizable    = true
+  max_characters = 255
+}
+`),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("akeneo_example.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "labels.%", "2"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "labels.de_DE", "Produktname"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "max_characters", "255"),
 				),
 			},
-			// Delete testing automatically occurs in TestCase
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute.test"),
+			},
 		},
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
-	return fmt.Sprintf(`
-resource "akeneo_example" "test" {
-  configurable_attribute = %[1]q
+func TestAccAttributeResource_metric(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_attribute" "test" {
+  code  = "weight"
+  type  = "pim_catalog_metric"
+  group = "other"
+
+  metric_family       = "Weight"
+  default_metric_unit = "KILOGRAM"
+  decimals_allowed    = true
 }
-`, configurableAttribute)
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "metric_family", "Weight"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "default_metric_unit", "KILOGRAM"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "decimals_allowed", "true"),
+					resource.TestCheckResourceAttr("akeneo_attribute.test", "negative_allowed", "false"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute.test"),
+			},
+		},
+	})
+}
+
+func TestAttributeResourceUpgradeState_v0(t *testing.T) {
+	var data AttributeResourceModel
+	testUpgradeState(t, NewAttributeResource(), 0, `{
+  "code": "picture",
+  "type": "pim_catalog_image",
+  "labels": {"en_US": "Picture"},
+  "group": "media",
+  "localizable": true,
+  "scopable": false,
+  "available_locales": ["en_US", "de_DE", "en_US"],
+  "allowed_extensions": ["jpg", "png"],
+  "max_file_size": 10
+}`, &data)
+
+	labels := testLabelsMap(t, map[string]string{"en_US": "Picture"})
+	if !data.Labels.Equal(labels) || !data.EffectiveLabels.Equal(labels) || !data.Label.IsNull() {
+		t.Errorf("expected labels and effective_labels %s without label, got %s, %s and %s", labels, data.Labels, data.EffectiveLabels, data.Label)
+	}
+	if expected := testStringSet("en_US", "de_DE"); !data.AvailableLocales.Equal(expected) {
+		t.Errorf("expected available_locales %s, got %s", expected, data.AvailableLocales)
+	}
+	if expected := testStringSet("jpg", "png"); !data.AllowedExtensions.Equal(expected) {
+		t.Errorf("expected allowed_extensions %s, got %s", expected, data.AllowedExtensions)
+	}
+	if data.MaxFileSize.ValueInt64() != 10 || !data.Localizable.ValueBool() {
+		t.Errorf("expected max_file_size and localizable to be kept, got %s and %s", data.MaxFileSize, data.Localizable)
+	}
 }
-*/
@@ -5,14 +5,17 @@ import (
 	"fmt"
 
 	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
-	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -21,6 +24,7 @@ import (
 var _ resource.Resource = &CategoryResource{}
 var _ resource.ResourceWithImportState = &CategoryResource{}
 var _ resource.ResourceWithConfigure = &CategoryResource{}
+var _ resource.ResourceWithModifyPlan = &CategoryResource{}
 
 func NewCategoryResource() resource.Resource {
 	return &CategoryResource{}
@@ -29,13 +33,20 @@ func NewCategoryResource() resource.Resource {
 // CategoryResource defines the resource implementation.
 type CategoryResource struct {
 	client *akeneox.CategoryService
+	labels labelDefaults
 }
 
 // CategoryResourceModel describes the resource data model.
 type CategoryResourceModel struct {
-	Code   types.String `tfsdk:"code"`
-	Parent types.String `tfsdk:"parent"`
-	Labels types.Map    `tfsdk:"labels"`
+	Code                types.String         `tfsdk:"code"`
+	Parent              types.String         `tfsdk:"parent"`
+	Position            types.Int64          `tfsdk:"position"`
+	Labels              types.Map            `tfsdk:"labels"`
+	Label               types.String         `tfsdk:"label"`
+	EffectiveLabels     types.Map            `tfsdk:"effective_labels"`
+	Values              []CategoryValueModel `tfsdk:"values"`
+	ChannelRequirements types.Set            `tfsdk:"channel_requirements"`
+	MediaCodes          types.Map            `tfsdk:"media_codes"`
 }
 
 func (r *CategoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +66,18 @@ func (r *CategoryResource) Schema(ctx context.Context, req resource.SchemaReques
 				Description: "Category parent",
 				Optional:    true,
 			},
+			"position": schema.Int64Attribute{
+				Description: "Position of the category among the children of its parent, starting at 1. Only available since Akeneo 7",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("parent")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"labels": schema.MapAttribute{
 				Description: "Label definition per locale",
 				Optional:    true,
@@ -63,6 +86,22 @@ func (r *CategoryResource) Schema(ctx context.Context, req resource.SchemaReques
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
+			"values":           categoryValuesSchema("Enriched category attribute values defined by the category template. Only available since Akeneo 7"),
+			"channel_requirements": schema.SetAttribute{
+				Description: "Codes of the channels for which the category values are required",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"media_codes": schema.MapAttribute{
+				Description: "Media codes of the files uploaded for the values, keyed by the attribute code followed by the channel and locale separated by \"|\"",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -91,9 +130,52 @@ func (r *CategoryResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewCategoryClient(data.Client)
 }
 
+func (r *CategoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+
+	// Nothing to validate on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data, state CategoryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, v := range data.Values {
+		if !v.File.IsNull() && !v.Type.IsUnknown() && v.Type.ValueString() != "image" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("values"),
+				"Invalid category value",
+				"Files can only be uploaded for image attributes, attribute "+v.Attribute.ValueString()+" is of type "+v.Type.ValueString()+".",
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The media codes are only known after the files are uploaded again.
+	if !r.mediaFilesUnchanged(ctx, &resp.Diagnostics, &data, &state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("media_codes"), types.MapUnknown(types.StringType))...)
+	}
+}
+
 func (r *CategoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data CategoryResourceModel
 
@@ -104,7 +186,13 @@ func (r *CategoryResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data)
+	mediaCodes := r.uploadMediaFiles(ctx, &resp.Diagnostics, &data, nil)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data, mediaCodes)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -120,6 +208,23 @@ func (r *CategoryResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// The position is assigned by Akeneo when not configured.
+	if data.Position.IsUnknown() {
+		created, err := r.client.GetCategory(data.Code.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error while reading a category",
+				"An unexpected error occurred when reading created category. \n\n"+
+					"Akeneo API Error: "+err.Error(),
+			)
+			return
+		}
+		data.Position = types.Int64Null()
+		if created.Position != nil {
+			data.Position = types.Int64Value(int64(*created.Position))
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -163,21 +268,56 @@ func (r *CategoryResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *CategoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data CategoryResourceModel
+	var data, state CategoryResourceModel
+	var position types.Int64
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("position"), &position)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mediaCodes := r.uploadMediaFiles(ctx, &resp.Diagnostics, &data, &state)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data)
+	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data, mediaCodes)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
+	// Only move the category when the position is configured, the planned
+	// position may be a stale computed value from a previous parent.
+	if position.IsNull() {
+		apiData.Position = nil
+	}
+
+	// PATCH merges values, so values removed from the configuration have to be emptied explicitly.
+	for _, v := range state.Values {
+		key := categoryValueKey(v.Attribute.ValueString(), v.Scope.ValueStringPointer(), v.Locale.ValueStringPointer())
+		if _, ok := apiData.Values[key]; ok {
+			continue
+		}
+		if apiData.Values == nil {
+			apiData.Values = make(map[string]akeneox.CategoryValue)
+		}
+		apiData.Values[key] = akeneox.CategoryValue{
+			Data:          nil,
+			Type:          v.Type.ValueString(),
+			Channel:       v.Scope.ValueStringPointer(),
+			Locale:        v.Locale.ValueStringPointer(),
+			AttributeCode: v.Attribute.ValueString(),
+		}
+	}
+
 	_, err := r.client.UpdateCategory(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -216,22 +356,23 @@ func (r *CategoryResource) ImportState(ctx context.Context, req resource.ImportS
 	resource.ImportStatePassthroughID(ctx, path.Root("code"), req, resp)
 }
 
-func (r *CategoryResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *CategoryResourceModel) *goakeneo.Category {
-	a := goakeneo.Category{
+func (r *CategoryResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *CategoryResourceModel, mediaCodes map[string]string) *akeneox.Category {
+	a := akeneox.Category{
 		Code:   data.Code.ValueString(),
 		Parent: data.Parent.ValueStringPointer(),
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
+	if !(data.Position.IsNull() || data.Position.IsUnknown()) {
+		position := int(data.Position.ValueInt64())
+		a.Position = &position
 	}
 
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
+
+	a.Values = categoryValuesToApi(diags, data.Values, mediaCodes)
+
+	a.ChannelRequirements = typesx.SetElements[string](ctx, diags, data.ChannelRequirements)
+
 	if diags.HasError() {
 		return nil
 	}
@@ -239,21 +380,97 @@ func (r *CategoryResource) mapToApiObject(ctx context.Context, diags *diag.Diagn
 	return &a
 }
 
-func (r *CategoryResource) mapToTfObject(respDiags *diag.Diagnostics, data *CategoryResourceModel, apiData *goakeneo.Category) {
+func (r *CategoryResource) mapToTfObject(respDiags *diag.Diagnostics, data *CategoryResourceModel, apiData *akeneox.Category) {
 	data.Code = types.StringValue(apiData.Code)
 	data.Parent = types.StringPointerValue(apiData.Parent)
 
-	if len(apiData.Labels) > 0 {
-		elements := make(map[string]attr.Value, len(apiData.Labels))
+	data.Position = types.Int64Null()
+	if apiData.Position != nil {
+		data.Position = types.Int64Value(int64(*apiData.Position))
+	}
+
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, apiData.Labels)
+
+	mediaCodes := make(map[string]string)
+	for k, v := range data.MediaCodes.Elements() {
+		if code, ok := v.(types.String); ok {
+			mediaCodes[k] = code.ValueString()
+		}
+	}
+
+	data.Values = categoryValuesFromApi(respDiags, data.Values, apiData.Values, mediaCodes)
+
+	data.ChannelRequirements = typesx.SetValue(respDiags, apiData.ChannelRequirements)
+}
+
+// mediaFilesUnchanged reports whether every planned file value was already
+// uploaded with the same content hash.
+func (r *CategoryResource) mediaFilesUnchanged(ctx context.Context, diags *diag.Diagnostics, data, state *CategoryResourceModel) bool {
+	mediaCodes := typesx.MapElements[string](ctx, diags, state.MediaCodes)
+
+	uploaded := make(map[string]types.String, len(state.Values))
+	for _, v := range state.Values {
+		if !v.File.IsNull() {
+			uploaded[categoryValueKey(v.Attribute.ValueString(), v.Scope.ValueStringPointer(), v.Locale.ValueStringPointer())] = v.FileSha256
+		}
+	}
+
+	files := 0
+	for _, v := range data.Values {
+		if v.File.IsNull() {
+			continue
+		}
+		files++
+
+		key := categoryValueKey(v.Attribute.ValueString(), v.Scope.ValueStringPointer(), v.Locale.ValueStringPointer())
+		hash, ok := uploaded[key]
+		if !ok || v.FileSha256.IsUnknown() || hash.IsNull() || !hash.Equal(v.FileSha256) || mediaCodes[key] == "" {
+			return false
+		}
+	}
+
+	return files == len(mediaCodes)
+}
 
-		for k, v := range apiData.Labels {
-			elements[k] = types.StringValue(v)
+// uploadMediaFiles uploads the files of the planned values and stores their
+// media codes in the model. Files with an unchanged hash are not uploaded again.
+func (r *CategoryResource) uploadMediaFiles(ctx context.Context, diags *diag.Diagnostics, data, state *CategoryResourceModel) map[string]string {
+	var prior map[string]string
+	uploaded := make(map[string]types.String)
+	if state != nil {
+		prior = typesx.MapElements[string](ctx, diags, state.MediaCodes)
+		for _, v := range state.Values {
+			if !v.File.IsNull() {
+				uploaded[categoryValueKey(v.Attribute.ValueString(), v.Scope.ValueStringPointer(), v.Locale.ValueStringPointer())] = v.FileSha256
+			}
 		}
+	}
 
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
+	mediaCodes := make(map[string]string)
+	for _, v := range data.Values {
+		if v.File.IsNull() {
+			continue
 		}
-		data.Labels = mapVal
+
+		key := categoryValueKey(v.Attribute.ValueString(), v.Scope.ValueStringPointer(), v.Locale.ValueStringPointer())
+		if hash, ok := uploaded[key]; ok && !hash.IsNull() && hash.Equal(v.FileSha256) && prior[key] != "" {
+			mediaCodes[key] = prior[key]
+			continue
+		}
+
+		code, err := r.client.UploadCategoryMediaFile(v.File.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Error while uploading a category media file",
+				"An unexpected error occurred when uploading file "+v.File.ValueString()+". \n\n"+
+					"Akeneo API Error: "+err.Error(),
+			)
+			continue
+		}
+		mediaCodes[key] = code
 	}
+
+	data.MediaCodes = typesx.MapValue(diags, mediaCodes)
+
+	return mediaCodes
 }
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCategoryResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_category" "summer" {
+  code   = "summer"
+  parent = "master"
+  labels = { en_US = "Summer" }
+}
+
+resource "akeneo_category" "winter" {
+  code   = "winter"
+  parent = "master"
+  labels = { en_US = "Winter" }
+
+  depends_on = [akeneo_category.summer]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_category.summer", "parent", "master"),
+					resource.TestCheckResourceAttr("akeneo_category.summer", "position", "1"),
+					resource.TestCheckResourceAttr("akeneo_category.winter", "position", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_category.summer",
+				ImportState:                          true,
+				ImportStateId:                        "summer",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_category" "summer" {
+  code     = "summer"
+  parent   = "master"
+  position = 2
+  labels   = { en_US = "Summer", de_DE = "Sommer" }
+}
+
+resource "akeneo_category" "winter" {
+  code     = "winter"
+  parent   = "master"
+  position = 1
+  labels   = { en_US = "Winter" }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_category.summer", "labels.de_DE", "Sommer"),
+					resource.TestCheckResourceAttr("akeneo_category.summer", "position", "2"),
+					resource.TestCheckResourceAttr("akeneo_category.winter", "position", "1"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_category.summer", "akeneo_category.winter"),
+			},
+		},
+	})
+}
+
+func TestAccCategoryResource_values(t *testing.T) {
+	server := testAccServer(t)
+
+	image := filepath.Join(t.TempDir(), "summer.jpg")
+	if err := os.WriteFile(image, []byte("image content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_category" "test" {
+  code   = "summer"
+  parent = "master"
+
+  values = [
+    {
+      attribute = "description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26"
+      type      = "text"
+      locale    = "en_US"
+      scope     = "ecommerce"
+      data      = jsonencode("Summer collection")
+    },
+    {
+      attribute   = "banner|8dda490c-0fd1-4485-bdc5-342929783d9a"
+      type        = "image"
+      file        = "`+filepath.ToSlash(image)+`"
+      file_sha256 = filesha256("`+filepath.ToSlash(image)+`")
+    },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_category.test", "values.#", "2"),
+					resource.TestCheckResourceAttr("akeneo_category.test", "media_codes.%", "1"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_category.test"),
+			},
+		},
+	})
+}
@@ -0,0 +1,355 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CategoryTemplateResource{}
+var _ resource.ResourceWithImportState = &CategoryTemplateResource{}
+var _ resource.ResourceWithConfigure = &CategoryTemplateResource{}
+var _ resource.ResourceWithModifyPlan = &CategoryTemplateResource{}
+
+func NewCategoryTemplateResource() resource.Resource {
+	return &CategoryTemplateResource{}
+}
+
+// CategoryTemplateResource defines the resource implementation.
+type CategoryTemplateResource struct {
+	client *akeneox.CategoryService
+	labels labelDefaults
+}
+
+type CategoryTemplateAttributeModel struct {
+	Code          types.String `tfsdk:"code"`
+	Type          types.String `tfsdk:"type"`
+	IsLocalizable types.Bool   `tfsdk:"is_localizable"`
+	IsScopable    types.Bool   `tfsdk:"is_scopable"`
+	IsRequired    types.Bool   `tfsdk:"is_required"`
+	Labels        types.Map    `tfsdk:"labels"`
+}
+
+// CategoryTemplateResourceModel describes the resource data model.
+type CategoryTemplateResourceModel struct {
+	Code            types.String                     `tfsdk:"code"`
+	CategoryTree    types.String                     `tfsdk:"category_tree"`
+	Labels          types.Map                        `tfsdk:"labels"`
+	Label           types.String                     `tfsdk:"label"`
+	EffectiveLabels types.Map                        `tfsdk:"effective_labels"`
+	Attributes      []CategoryTemplateAttributeModel `tfsdk:"attributes"`
+}
+
+func (r *CategoryTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_category_template"
+}
+
+func (r *CategoryTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Akeneo category template resource. Defines the enriched attributes of the categories in a category tree. Only available since Akeneo 7",
+
+		Attributes: map[string]schema.Attribute{
+			"code": schema.StringAttribute{
+				Description: "Category template code",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"category_tree": schema.StringAttribute{
+				Description: "Code of the root category of the tree the template is attached to",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				Description: "Label definition per locale",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
+				},
+			},
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
+			"attributes": schema.ListNestedAttribute{
+				Description: "Template attributes in the order they are displayed",
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							Description: "Attribute code",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Attribute type, one of: text, textarea, richtext, image",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(categoryValueTypes...),
+							},
+						},
+						"is_localizable": schema.BoolAttribute{
+							Description: "Whether the attribute value depends on the locale",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"is_scopable": schema.BoolAttribute{
+							Description: "Whether the attribute value depends on the channel",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"is_required": schema.BoolAttribute{
+							Description: "Whether the attribute value is required for the channel requirements of the category",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"labels": schema.MapAttribute{
+							Description: "Label definition per locale",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *CategoryTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	if data.Client == nil {
+		resp.Diagnostics.AddError(
+			"Missing client instance",
+			"Client instance pointer passed to Configure is required, got nil",
+		)
+		return
+	}
+
+	r.labels = newLabelDefaults(data)
+	r.client = akeneox.NewCategoryClient(data.Client)
+}
+
+func (r *CategoryTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+}
+
+func (r *CategoryTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CategoryTemplateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateCategoryTemplate(*apiData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating a category template",
+			"An unexpected error occurred when creating category template. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CategoryTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CategoryTemplateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData, err := r.client.GetCategoryTemplate(data.Code.ValueString())
+	if err != nil {
+		if akeneox.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error while reading a category template",
+			"An unexpected error occurred when reading category template. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.mapToTfObject(&resp.Diagnostics, &data, apiData)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CategoryTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CategoryTemplateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
+	err := r.client.UpdateCategoryTemplate(*apiData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while updating a category template",
+			"An unexpected error occurred when updating category template. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CategoryTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CategoryTemplateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteCategoryTemplate(data.Code.ValueString())
+	if err != nil && !akeneox.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error while deleting a category template",
+			"An unexpected error occurred when deleting category template. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *CategoryTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("code"), req, resp)
+}
+
+func (r *CategoryTemplateResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *CategoryTemplateResourceModel) *akeneox.CategoryTemplate {
+	a := akeneox.CategoryTemplate{
+		Code:         data.Code.ValueString(),
+		CategoryTree: data.CategoryTree.ValueString(),
+		Labels:       labelsToApi(ctx, diags, data.EffectiveLabels),
+	}
+
+	if data.Attributes != nil {
+		a.Attributes = make([]akeneox.CategoryTemplateAttribute, len(data.Attributes))
+		for i, v := range data.Attributes {
+			a.Attributes[i] = akeneox.CategoryTemplateAttribute{
+				Code:          v.Code.ValueString(),
+				Type:          v.Type.ValueString(),
+				IsLocalizable: v.IsLocalizable.ValueBool(),
+				IsScopable:    v.IsScopable.ValueBool(),
+				IsRequired:    v.IsRequired.ValueBool(),
+				Labels:        labelsToApi(ctx, diags, v.Labels),
+			}
+		}
+	}
+
+	if diags.HasError() {
+		return nil
+	}
+
+	return &a
+}
+
+func (r *CategoryTemplateResource) mapToTfObject(respDiags *diag.Diagnostics, data *CategoryTemplateResourceModel, apiData *akeneox.CategoryTemplate) {
+	data.Code = types.StringValue(apiData.Code)
+	data.CategoryTree = types.StringValue(apiData.CategoryTree)
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, apiData.Labels)
+
+	if len(apiData.Attributes) > 0 {
+		attributes := make([]CategoryTemplateAttributeModel, len(apiData.Attributes))
+		for i, v := range apiData.Attributes {
+			attributes[i] = CategoryTemplateAttributeModel{
+				Code:          types.StringValue(v.Code),
+				Type:          types.StringValue(v.Type),
+				IsLocalizable: types.BoolValue(v.IsLocalizable),
+				IsScopable:    types.BoolValue(v.IsScopable),
+				IsRequired:    types.BoolValue(v.IsRequired),
+				Labels:        typesx.MapValue(respDiags, v.Labels),
+			}
+		}
+		data.Attributes = attributes
+	}
+}
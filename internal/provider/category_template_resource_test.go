@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCategoryTemplateResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_category_template" "test" {
+  code          = "master_template"
+  category_tree = "master"
+  labels        = { en_US = "Master template" }
+
+  attributes = [
+    {
+      code           = "description"
+      type           = "textarea"
+      is_localizable = true
+      labels         = { en_US = "Description" }
+    },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_category_template.test", "category_tree", "master"),
+					resource.TestCheckResourceAttr("akeneo_category_template.test", "attributes.#", "1"),
+					resource.TestCheckResourceAttr("akeneo_category_template.test", "attributes.0.is_localizable", "true"),
+					resource.TestCheckResourceAttr("akeneo_category_template.test", "attributes.0.is_scopable", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_category_template.test",
+				ImportState:                          true,
+				ImportStateId:                        "master_template",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_category_template" "test" {
+  code          = "master_template"
+  category_tree = "master"
+  labels        = { en_US = "Master template" }
+
+  attributes = [
+    {
+      code           = "description"
+      type           = "textarea"
+      is_localizable = true
+      labels         = { en_US = "Description" }
+    },
+    {
+      code        = "banner"
+      type        = "image"
+      is_required = true
+    },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_category_template.test", "attributes.#", "2"),
+					resource.TestCheckResourceAttr("akeneo_category_template.test", "attributes.1.is_required", "true"),
+				),
+			},
+		},
+	})
+}
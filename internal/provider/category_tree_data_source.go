@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CategoryTreeDataSource{}
+var _ datasource.DataSourceWithConfigure = &CategoryTreeDataSource{}
+
+func NewCategoryTreeDataSource() datasource.DataSource {
+	return &CategoryTreeDataSource{}
+}
+
+// CategoryTreeDataSource defines the data source implementation.
+type CategoryTreeDataSource struct {
+	client *akeneox.CategoryService
+}
+
+type CategoryTreeNodeModel struct {
+	Code   types.String `tfsdk:"code"`
+	Parent types.String `tfsdk:"parent"`
+	Depth  types.Int64  `tfsdk:"depth"`
+	Path   types.List   `tfsdk:"path"`
+	Labels types.Map    `tfsdk:"labels"`
+}
+
+// CategoryTreeDataSourceModel describes the data source data model.
+type CategoryTreeDataSourceModel struct {
+	Root       types.String            `tfsdk:"root"`
+	MaxDepth   types.Int64             `tfsdk:"max_depth"`
+	Codes      types.Set               `tfsdk:"codes"`
+	Categories []CategoryTreeNodeModel `tfsdk:"categories"`
+}
+
+func (d *CategoryTreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_category_tree"
+}
+
+func (d *CategoryTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Akeneo category tree data source. Returns the root category and all of its descendants.",
+
+		Attributes: map[string]schema.Attribute{
+			"root": schema.StringAttribute{
+				Description: "Code of the category tree, a category without a parent",
+				Required:    true,
+			},
+			"max_depth": schema.Int64Attribute{
+				Description: "Maximum depth of descendants to load, the root has depth 0. All descendants are loaded when not set",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"codes": schema.SetAttribute{
+				Description: "Codes of all categories in the tree, including the root",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"categories": schema.ListNestedAttribute{
+				Description: "Flattened tree in depth-first order, starting with the root",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							Description: "Category code",
+							Computed:    true,
+						},
+						"parent": schema.StringAttribute{
+							Description: "Category parent",
+							Computed:    true,
+						},
+						"depth": schema.Int64Attribute{
+							Description: "Distance from the root category",
+							Computed:    true,
+						},
+						"path": schema.ListAttribute{
+							Description: "Category codes from the root down to this category",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"labels": schema.MapAttribute{
+							Description: "Label definition per locale",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CategoryTreeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*DataSourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DataSourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	if data.Client == nil {
+		resp.Diagnostics.AddError(
+			"Missing client instance",
+			"Client instance pointer passed to Configure is required, got nil",
+		)
+		return
+	}
+
+	d.client = akeneox.NewCategoryClient(data.Client)
+}
+
+func (d *CategoryTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CategoryTreeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The whole catalog is listed once and the tree is built from the parents,
+	// the API has no filter for the categories of a tree.
+	categories, err := d.client.ListCategories()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading a category tree",
+			"An unexpected error occurred when listing categories. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	root, children := categoryTree(categories, data.Root.ValueString())
+	switch {
+	case root == nil:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("root"),
+			"Category tree not found",
+			"The category tree "+data.Root.ValueString()+" does not exist in Akeneo.",
+		)
+		return
+	case root.Parent != nil:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("root"),
+			"Category is not a category tree",
+			"The category "+data.Root.ValueString()+" has the parent "+*root.Parent+", only a category without a parent is the root of a category tree.",
+		)
+		return
+	}
+
+	maxDepth := -1
+	if !data.MaxDepth.IsNull() {
+		maxDepth = int(data.MaxDepth.ValueInt64())
+	}
+
+	data.Categories = make([]CategoryTreeNodeModel, 0)
+	codes := make([]attr.Value, 0)
+
+	var walk func(category akeneox.Category, path []string)
+	walk = func(category akeneox.Category, path []string) {
+		path = append(path[:len(path):len(path)], category.Code)
+		data.Categories = append(data.Categories, d.mapToTfObject(&resp.Diagnostics, category, path))
+		codes = append(codes, types.StringValue(category.Code))
+
+		if maxDepth >= 0 && len(path)-1 >= maxDepth {
+			return
+		}
+
+		for _, child := range children[category.Code] {
+			walk(child, path)
+		}
+	}
+
+	walk(*root, nil)
+
+	setVal, diags := types.SetValue(types.StringType, codes)
+	resp.Diagnostics.Append(diags...)
+	data.Codes = setVal
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// categoryTree returns the category with the root code and the children of
+// every category ordered by their position, the root is nil when it does not
+// exist.
+func categoryTree(categories []akeneox.Category, rootCode string) (*akeneox.Category, map[string][]akeneox.Category) {
+	var root *akeneox.Category
+	children := make(map[string][]akeneox.Category)
+	for i, category := range categories {
+		if category.Code == rootCode {
+			root = &categories[i]
+		}
+		if category.Parent != nil {
+			children[*category.Parent] = append(children[*category.Parent], category)
+		}
+	}
+
+	for _, siblings := range children {
+		sort.SliceStable(siblings, func(i, j int) bool {
+			if siblings[i].Position == nil || siblings[j].Position == nil {
+				return false
+			}
+			return *siblings[i].Position < *siblings[j].Position
+		})
+	}
+
+	return root, children
+}
+
+func (d *CategoryTreeDataSource) mapToTfObject(respDiags *diag.Diagnostics, apiData akeneox.Category, path []string) CategoryTreeNodeModel {
+	return CategoryTreeNodeModel{
+		Code:   types.StringValue(apiData.Code),
+		Parent: types.StringPointerValue(apiData.Parent),
+		Depth:  types.Int64Value(int64(len(path) - 1)),
+		Path:   typesx.ListValue(respDiags, path),
+		Labels: typesx.MapValue(respDiags, apiData.Labels),
+	}
+}
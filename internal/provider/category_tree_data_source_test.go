@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccCategoryTreeCategoriesConfig = `
+resource "akeneo_category" "clothing" {
+  code   = "clothing"
+  parent = "master"
+}
+
+resource "akeneo_category" "shirts" {
+  code   = "shirts"
+  parent = akeneo_category.clothing.code
+}
+`
+
+func TestAccCategoryTreeDataSource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server, testAccCategoryTreeCategoriesConfig+`
+data "akeneo_category_tree" "test" {
+  root = "master"
+
+  depends_on = [akeneo_category.shirts]
+}
+
+data "akeneo_category_tree" "shallow" {
+  root      = "master"
+  max_depth = 1
+
+  depends_on = [akeneo_category.shirts]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akeneo_category_tree.test", "codes.#", "3"),
+					resource.TestCheckResourceAttr("data.akeneo_category_tree.test", "categories.0.code", "master"),
+					resource.TestCheckResourceAttr("data.akeneo_category_tree.test", "categories.2.code", "shirts"),
+					resource.TestCheckResourceAttr("data.akeneo_category_tree.test", "categories.2.depth", "2"),
+					resource.TestCheckResourceAttr("data.akeneo_category_tree.test", "categories.2.path.#", "3"),
+					resource.TestCheckResourceAttr("data.akeneo_category_tree.shallow", "codes.#", "2"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server, testAccCategoryTreeCategoriesConfig+`
+data "akeneo_category_tree" "test" {
+  root = "clothing"
+
+  depends_on = [akeneo_category.shirts]
+}
+`),
+				ExpectError: regexp.MustCompile(`Category is not a category tree`),
+			},
+			{
+				Config: testAccProviderConfig(server, testAccCategoryTreeCategoriesConfig+`
+data "akeneo_category_tree" "test" {
+  root = "unknown"
+}
+`),
+				ExpectError: regexp.MustCompile(`Category tree not found`),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_category.clothing", "akeneo_category.shirts"),
+			},
+		},
+	})
+}
+
+func TestCategoryTree(t *testing.T) {
+	parent := func(code string) *string { return &code }
+	position := func(p int) *int { return &p }
+	categories := []akeneox.Category{
+		{Code: "shirts", Parent: parent("clothing"), Position: position(2)},
+		{Code: "master"},
+		{Code: "trousers", Parent: parent("clothing"), Position: position(1)},
+		{Code: "clothing", Parent: parent("master"), Position: position(1)},
+		{Code: "sales"},
+	}
+
+	root, children := categoryTree(categories, "master")
+	if root == nil || root.Code != "master" {
+		t.Fatalf("expected root master, got %v", root)
+	}
+
+	codes := func(categories []akeneox.Category) []string {
+		result := make([]string, len(categories))
+		for i, category := range categories {
+			result[i] = category.Code
+		}
+		return result
+	}
+	if got := codes(children["master"]); !reflect.DeepEqual(got, []string{"clothing"}) {
+		t.Errorf("expected children [clothing] of master, got %v", got)
+	}
+	if got := codes(children["clothing"]); !reflect.DeepEqual(got, []string{"trousers", "shirts"}) {
+		t.Errorf("expected children [trousers shirts] of clothing ordered by position, got %v", got)
+	}
+
+	if root, _ := categoryTree(categories, "unknown"); root != nil {
+		t.Errorf("expected no root for an unknown code, got %v", root)
+	}
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var categoryValueTypes = []string{"text", "textarea", "richtext", "image"}
+
+// CategoryValueModel describes a single enriched category attribute value. It
+// mirrors ProductValueModel, with the category attribute type added as the API
+// requires it for every value.
+type CategoryValueModel struct {
+	Attribute  types.String `tfsdk:"attribute"`
+	Type       types.String `tfsdk:"type"`
+	Locale     types.String `tfsdk:"locale"`
+	Scope      types.String `tfsdk:"scope"`
+	Data       types.String `tfsdk:"data"`
+	File       types.String `tfsdk:"file"`
+	FileSha256 types.String `tfsdk:"file_sha256"`
+}
+
+func categoryValuesSchema(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Description: description,
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"attribute": schema.StringAttribute{
+					Description: "Category attribute code, including the template attribute identifier (e.g. description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26)",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"type": schema.StringAttribute{
+					Description: "Category attribute type, one of: " + strings.Join(categoryValueTypes, ", "),
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(categoryValueTypes...),
+					},
+				},
+				"locale": schema.StringAttribute{
+					Description: "Locale of the value when the attribute is localizable",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidatorx.IsLocaleCode(),
+					},
+				},
+				"scope": schema.StringAttribute{
+					Description: "Channel code of the value when the attribute is scopable",
+					Optional:    true,
+				},
+				"data": schema.StringAttribute{
+					Description: "JSON encoded value data. Example: jsonencode(\"<p>Summer collection</p>\")",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidatorx.IsJSON(),
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("file")),
+					},
+				},
+				"file": schema.StringAttribute{
+					Description: "Path to a local file uploaded as the value of an image attribute",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+						stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("file_sha256")),
+					},
+				},
+				"file_sha256": schema.StringAttribute{
+					Description: "SHA256 hash of the file content, the file is uploaded again when it changes. Example: filesha256(\"images/summer.jpg\")",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("file")),
+					},
+				},
+			},
+		},
+	}
+}
+
+// categoryValueKey builds the key under which the API stores a category value.
+func categoryValueKey(attribute string, scope, locale *string) string {
+	key := attribute
+	if scope != nil {
+		key += "|" + *scope
+	}
+	if locale != nil {
+		key += "|" + *locale
+	}
+	return key
+}
+
+// categoryValueFileData builds the image value data referencing an uploaded media file.
+func categoryValueFileData(mediaCode string, file string) map[string]any {
+	return map[string]any{
+		"file_path":         mediaCode,
+		"original_filename": filepath.Base(file),
+	}
+}
+
+// categoryValueMediaCode returns the media code of an image value data.
+func categoryValueMediaCode(data any) string {
+	if m, ok := data.(map[string]any); ok {
+		if code, ok := m["file_path"].(string); ok {
+			return code
+		}
+	}
+	return ""
+}
+
+// categoryValuesToApi maps the values to the api format, values with a file
+// reference the uploaded media file from mediaCodes keyed by the value key.
+func categoryValuesToApi(diags *diag.Diagnostics, values []CategoryValueModel, mediaCodes map[string]string) map[string]akeneox.CategoryValue {
+	if values == nil {
+		return nil
+	}
+
+	result := make(map[string]akeneox.CategoryValue, len(values))
+	for _, v := range values {
+		key := categoryValueKey(v.Attribute.ValueString(), v.Scope.ValueStringPointer(), v.Locale.ValueStringPointer())
+
+		var data any
+		if !v.File.IsNull() {
+			data = categoryValueFileData(mediaCodes[key], v.File.ValueString())
+		} else if err := json.Unmarshal([]byte(v.Data.ValueString()), &data); err != nil {
+			diags.AddError(
+				"Invalid value data",
+				"Value data of category attribute "+v.Attribute.ValueString()+" is not a valid JSON document. \n\n"+
+					"Error: "+err.Error(),
+			)
+			continue
+		}
+
+		result[key] = akeneox.CategoryValue{
+			Data:          data,
+			Type:          v.Type.ValueString(),
+			Channel:       v.Scope.ValueStringPointer(),
+			Locale:        v.Locale.ValueStringPointer(),
+			AttributeCode: v.Attribute.ValueString(),
+		}
+	}
+
+	return result
+}
+
+// categoryValuesFromApi maps api values back to the model, following the same
+// rules as productValuesFromApi. Values uploaded from a file keep the file
+// reference, unless the media code differs from the one in mediaCodes, in which
+// case the hash is cleared so the file is uploaded again.
+func categoryValuesFromApi(diags *diag.Diagnostics, prior []CategoryValueModel, values map[string]akeneox.CategoryValue, mediaCodes map[string]string) []CategoryValueModel {
+	if prior == nil {
+		return nil
+	}
+
+	managed := make(map[string]bool, len(prior))
+	for _, v := range prior {
+		managed[v.Attribute.ValueString()] = true
+	}
+
+	result := make([]CategoryValueModel, 0)
+	for _, v := range values {
+		if !managed[v.AttributeCode] {
+			continue
+		}
+
+		data, err := json.Marshal(v.Data)
+		if err != nil {
+			diags.AddError(
+				"Error encoding value data",
+				"Value data of category attribute "+v.AttributeCode+" could not be encoded. \n\n"+
+					"Error: "+err.Error(),
+			)
+			continue
+		}
+
+		m := CategoryValueModel{
+			Attribute:  types.StringValue(v.AttributeCode),
+			Type:       types.StringValue(v.Type),
+			Locale:     types.StringPointerValue(v.Locale),
+			Scope:      types.StringPointerValue(v.Channel),
+			Data:       types.StringValue(string(data)),
+			File:       types.StringNull(),
+			FileSha256: types.StringNull(),
+		}
+
+		for _, p := range prior {
+			if !(p.Attribute.Equal(m.Attribute) && p.Locale.Equal(m.Locale) && p.Scope.Equal(m.Scope)) {
+				continue
+			}
+
+			if !p.File.IsNull() {
+				m.Data = types.StringNull()
+				m.File = p.File
+				m.FileSha256 = p.FileSha256
+				key := categoryValueKey(v.AttributeCode, v.Channel, v.Locale)
+				if categoryValueMediaCode(v.Data) != mediaCodes[key] {
+					m.FileSha256 = types.StringNull()
+				}
+			} else if jsonEqual(p.Data.ValueString(), v.Data) {
+				m.Data = p.Data
+			}
+			break
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
@@ -3,8 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	goakeneo "github.com/ezifyio/go-akeneo"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
@@ -13,6 +16,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -21,6 +26,8 @@ import (
 var _ resource.Resource = &ChannelResource{}
 var _ resource.ResourceWithImportState = &ChannelResource{}
 var _ resource.ResourceWithConfigure = &ChannelResource{}
+var _ resource.ResourceWithModifyPlan = &ChannelResource{}
+var _ resource.ResourceWithUpgradeState = &ChannelResource{}
 
 func NewChannelResource() resource.Resource {
 	return &ChannelResource{}
@@ -28,17 +35,25 @@ func NewChannelResource() resource.Resource {
 
 // ChannelResource defines the resource implementation.
 type ChannelResource struct {
-	client *akeneox.ChannelService
+	client          *akeneox.ChannelService
+	localeClient    *akeneox.LocaleService
+	attributeClient *akeneox.AttributeService
+	catalog         *akeneox.CatalogSnapshot
+	measureClient   *akeneox.MeasurementFamilyStore
+	labels          labelDefaults
 }
 
 // ChannelResourceModel describes the resource data model.
 type ChannelResourceModel struct {
-	Code            types.String `tfsdk:"code"`
-	Labels          types.Map    `tfsdk:"labels"`
-	Locales         types.List   `tfsdk:"locales"`
-	Currencies      types.List   `tfsdk:"currencies"`
-	CategoryTree    types.String `tfsdk:"category_tree"`
-	ConversionUnits types.Map    `tfsdk:"conversion_units"`
+	Code             types.String `tfsdk:"code"`
+	Labels           types.Map    `tfsdk:"labels"`
+	Label            types.String `tfsdk:"label"`
+	EffectiveLabels  types.Map    `tfsdk:"effective_labels"`
+	Locales          types.Set    `tfsdk:"locales"`
+	Currencies       types.Set    `tfsdk:"currencies"`
+	CategoryTree     types.String `tfsdk:"category_tree"`
+	ConversionUnits  types.Map    `tfsdk:"conversion_units"`
+	ActivatedLocales types.Set    `tfsdk:"activated_locales"`
 }
 
 func (r *ChannelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,6 +63,7 @@ func (r *ChannelResource) Metadata(ctx context.Context, req resource.MetadataReq
 func (r *ChannelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Akeneo channel resource",
+		Version:             2,
 
 		Attributes: map[string]schema.Attribute{
 			"code": schema.StringAttribute{
@@ -62,12 +78,14 @@ func (r *ChannelResource) Schema(ctx context.Context, req resource.SchemaRequest
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
-			"locales": schema.ListAttribute{
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
+			"locales": schema.SetAttribute{
 				Description: "Locales assigned to the channel",
 				Required:    true,
 				ElementType: types.StringType,
 			},
-			"currencies": schema.ListAttribute{
+			"currencies": schema.SetAttribute{
 				Description: "Currencies assigned to the channel",
 				Required:    true,
 				ElementType: types.StringType,
@@ -77,10 +95,16 @@ func (r *ChannelResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Required:    true,
 			},
 			"conversion_units": schema.MapAttribute{
-				Description: "Converion units assigned to the chennel",
+				Description: "Conversion units assigned to the channel, maps a metric attribute code to a unit code of its measurement family",
 				Optional:    true,
-				ElementType: types.ListType{
-					ElemType: types.StringType,
+				ElementType: types.StringType,
+			},
+			"activated_locales": schema.SetAttribute{
+				Description: "Locales activated in Akeneo after applying the channel. Akeneo activates a locale when a channel references it and deactivates it when it is removed from its last channel",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
 				},
 			},
 		},
@@ -111,7 +135,434 @@ func (r *ChannelResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewChannelClient(data.Client)
+	r.localeClient = akeneox.NewLocaleClient(data.Client)
+	r.attributeClient = akeneox.NewAttributeClient(data.Client)
+	r.catalog = data.Catalog
+	r.measureClient = data.MeasurementFamilies
+}
+
+func (r *ChannelResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored conversion units as a map of string lists.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"code": schema.StringAttribute{
+						Required: true,
+					},
+					"labels": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"locales": schema.ListAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+					},
+					"currencies": schema.ListAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+					},
+					"category_tree": schema.StringAttribute{
+						Required: true,
+					},
+					"conversion_units": schema.MapAttribute{
+						Optional: true,
+						ElementType: types.ListType{
+							ElemType: types.StringType,
+						},
+					},
+					"activated_locales": schema.SetAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					Code             types.String `tfsdk:"code"`
+					Labels           types.Map    `tfsdk:"labels"`
+					Locales          types.List   `tfsdk:"locales"`
+					Currencies       types.List   `tfsdk:"currencies"`
+					CategoryTree     types.String `tfsdk:"category_tree"`
+					ConversionUnits  types.Map    `tfsdk:"conversion_units"`
+					ActivatedLocales types.Set    `tfsdk:"activated_locales"`
+				}
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := ChannelResourceModel{
+					Code:             prior.Code,
+					Labels:           prior.Labels,
+					Label:            types.StringNull(),
+					EffectiveLabels:  prior.Labels,
+					CategoryTree:     prior.CategoryTree,
+					ConversionUnits:  types.MapNull(types.StringType),
+					ActivatedLocales: prior.ActivatedLocales,
+				}
+
+				resp.Diagnostics.Append(upgradeChannelCodeLists(&data, prior.Locales, prior.Currencies)...)
+
+				// A channel has a single conversion unit per attribute, keep the first one.
+				if !prior.ConversionUnits.IsNull() {
+					elements := make(map[string]attr.Value)
+					for code, v := range prior.ConversionUnits.Elements() {
+						units, ok := v.(types.List)
+						if !ok || len(units.Elements()) == 0 {
+							continue
+						}
+						elements[code] = units.Elements()[0]
+					}
+
+					if len(elements) > 0 {
+						mapVal, diags := types.MapValue(types.StringType, elements)
+						resp.Diagnostics.Append(diags...)
+						data.ConversionUnits = mapVal
+					}
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+		// Version 1 stored the locales and currencies as ordered lists.
+		1: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"code": schema.StringAttribute{
+						Required: true,
+					},
+					"labels": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"locales": schema.ListAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+					},
+					"currencies": schema.ListAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+					},
+					"category_tree": schema.StringAttribute{
+						Required: true,
+					},
+					"conversion_units": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"activated_locales": schema.SetAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					Code             types.String `tfsdk:"code"`
+					Labels           types.Map    `tfsdk:"labels"`
+					Locales          types.List   `tfsdk:"locales"`
+					Currencies       types.List   `tfsdk:"currencies"`
+					CategoryTree     types.String `tfsdk:"category_tree"`
+					ConversionUnits  types.Map    `tfsdk:"conversion_units"`
+					ActivatedLocales types.Set    `tfsdk:"activated_locales"`
+				}
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := ChannelResourceModel{
+					Code:             prior.Code,
+					Labels:           prior.Labels,
+					Label:            types.StringNull(),
+					EffectiveLabels:  prior.Labels,
+					CategoryTree:     prior.CategoryTree,
+					ConversionUnits:  prior.ConversionUnits,
+					ActivatedLocales: prior.ActivatedLocales,
+				}
+
+				resp.Diagnostics.Append(upgradeChannelCodeLists(&data, prior.Locales, prior.Currencies)...)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// upgradeChannelCodeLists sets the locales and currencies stored as lists
+// before version 2.
+func upgradeChannelCodeLists(data *ChannelResourceModel, locales types.List, currencies types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	localeSet, d := stringListToSet(locales)
+	diags.Append(d...)
+	data.Locales = localeSet
+
+	currencySet, d := stringListToSet(currencies)
+	diags.Append(d...)
+	data.Currencies = currencySet
+
+	return diags
+}
+
+func (r *ChannelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+
+	// Nothing to check on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data, state ChannelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validateConversionUnits(ctx, &resp.Diagnostics, &data)
+
+	// Locales can only be deactivated by an update.
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Locales.Equal(state.Locales) {
+		return
+	}
+
+	// Changing the locales may activate or deactivate locales.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("activated_locales"), types.SetUnknown(types.StringType))...)
+
+	if data.Locales.IsUnknown() {
+		return
+	}
+
+	planned := make(map[string]bool, len(data.Locales.Elements()))
+	for _, v := range data.Locales.Elements() {
+		if locale, ok := v.(types.String); ok {
+			planned[locale.ValueString()] = true
+		}
+	}
+
+	removed := make(map[string]bool)
+	for _, v := range state.Locales.Elements() {
+		if locale, ok := v.(types.String); ok && !planned[locale.ValueString()] {
+			removed[locale.ValueString()] = true
+		}
+	}
+
+	if len(removed) == 0 {
+		return
+	}
+
+	usages, err := r.deactivatedLocaleUsages(data.Code.ValueString(), removed)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("locales"),
+			"Unable to check locale usage",
+			"An unexpected error occurred when checking whether the removed locales are still in use. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	for _, usage := range usages {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("locales"),
+			"Locale will be deactivated",
+			usage,
+		)
+	}
+}
+
+// validateConversionUnits checks that every conversion unit key is a metric
+// attribute and the unit belongs to the measurement family of the attribute.
+// Attributes and measurement families which do not exist yet are skipped, as
+// they may be created within the same apply.
+func (r *ChannelResource) validateConversionUnits(ctx context.Context, diags *diag.Diagnostics, data *ChannelResourceModel) {
+	if data.ConversionUnits.IsNull() || data.ConversionUnits.IsUnknown() {
+		return
+	}
+
+	families := make(map[string]*akeneox.MeasurementFamily)
+
+	for code, v := range data.ConversionUnits.Elements() {
+		unit, ok := v.(types.String)
+		if !ok || unit.IsUnknown() {
+			continue
+		}
+		attrPath := path.Root("conversion_units").AtMapKey(code)
+
+		attribute, err := r.attributeClient.GetAttribute(code)
+		if err != nil {
+			if !akeneox.IsNotFound(err) {
+				diags.AddAttributeError(
+					attrPath,
+					"Error while reading an attribute",
+					"An unexpected error occurred when reading attribute "+code+". \n\n"+
+						"Akeneo API Error: "+err.Error(),
+				)
+			}
+			continue
+		}
+
+		if attribute.Type != "pim_catalog_metric" {
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid conversion unit",
+				fmt.Sprintf("Attribute %s is of type %s, conversion units can only be set for pim_catalog_metric attributes.", code, attribute.Type),
+			)
+			continue
+		}
+
+		if attribute.MetricFamily == nil {
+			continue
+		}
+		metricFamily := *attribute.MetricFamily
+
+		family, ok := families[metricFamily]
+		if !ok {
+			family, err = r.measureClient.GetMeasurementFamily(metricFamily)
+			if err != nil && !akeneox.IsNotFound(err) {
+				diags.AddAttributeError(
+					attrPath,
+					"Error while reading a measurement family",
+					"An unexpected error occurred when reading measurement family "+metricFamily+". \n\n"+
+						"Akeneo API Error: "+err.Error(),
+				)
+				continue
+			}
+			families[metricFamily] = family
+		}
+
+		if family == nil {
+			continue
+		}
+
+		if _, ok := family.Units[unit.ValueString()]; !ok {
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid conversion unit",
+				fmt.Sprintf("Unit %s does not belong to the measurement family %s of attribute %s.", unit.ValueString(), family.Code, code),
+			)
+		}
+	}
+}
+
+// deactivatedLocaleUsages returns a description of every removed locale which
+// is not used by any other channel, and so will be deactivated, but is still
+// used in the catalog. The catalog is listed once per provider run.
+func (r *ChannelResource) deactivatedLocaleUsages(channel string, removed map[string]bool) ([]string, error) {
+	channels, err := r.catalog.Channels()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range channels {
+		if c.Code == channel {
+			continue
+		}
+		for _, locale := range c.Locales {
+			delete(removed, locale)
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	attributes, err := r.catalog.Attributes()
+	if err != nil {
+		return nil, err
+	}
+
+	families, err := r.catalog.Families()
+	if err != nil {
+		return nil, err
+	}
+
+	return localeUsages(channel, removed, attributes, families), nil
+}
+
+// localeUsages describes where the deactivated locales are still used: in
+// attribute or family labels, in attribute available locales and in family
+// attribute requirements of the channel which include localizable attributes,
+// as their completeness is no longer computed for the locales.
+func localeUsages(channel string, deactivated map[string]bool, attributes []goakeneo.Attribute, families []goakeneo.Family) []string {
+	used := make(map[string][]string)
+	localizable := make(map[string]bool)
+	for _, a := range attributes {
+		for locale := range a.Labels {
+			if deactivated[locale] {
+				used[locale] = append(used[locale], "labels of attribute "+a.Code)
+			}
+		}
+		for _, locale := range a.AvailableLocales {
+			if deactivated[locale] {
+				used[locale] = append(used[locale], "available_locales of attribute "+a.Code)
+			}
+		}
+		if a.Localizable != nil && *a.Localizable {
+			localizable[a.Code] = true
+		}
+	}
+
+	for _, f := range families {
+		for locale := range f.Labels {
+			if deactivated[locale] {
+				used[locale] = append(used[locale], "labels of family "+f.Code)
+			}
+		}
+
+		required := make([]string, 0)
+		for _, attribute := range f.AttributeRequirements[channel] {
+			if localizable[attribute] {
+				required = append(required, attribute)
+			}
+		}
+		if len(required) == 0 {
+			continue
+		}
+		sort.Strings(required)
+		for locale := range deactivated {
+			used[locale] = append(used[locale], fmt.Sprintf(
+				"attribute_requirements of family %s for channel %s with the localizable attributes %s",
+				f.Code, channel, strings.Join(required, ", "),
+			))
+		}
+	}
+
+	locales := make([]string, 0, len(used))
+	for locale := range used {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	usages := make([]string, len(locales))
+	for i, locale := range locales {
+		usages[i] = fmt.Sprintf(
+			"Locale %s is not used by any other channel and will be deactivated, but it is still used in: %s. "+
+				"Values and labels in a deactivated locale are ignored by Akeneo.",
+			locale,
+			strings.Join(used[locale], ", "),
+		)
+	}
+
+	return usages
 }
 
 func (r *ChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -140,6 +591,8 @@ func (r *ChannelResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	r.readActivatedLocales(&resp.Diagnostics, &data)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -173,6 +626,7 @@ func (r *ChannelResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	r.mapToTfObject(&resp.Diagnostics, &data, apiData)
+	r.readActivatedLocales(&resp.Diagnostics, &data)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -198,6 +652,8 @@ func (r *ChannelResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
 	_, err := r.client.UpdateChannel(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -208,6 +664,8 @@ func (r *ChannelResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	r.readActivatedLocales(&resp.Diagnostics, &data)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -236,54 +694,41 @@ func (r *ChannelResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("code"), req, resp)
 }
 
+func (r *ChannelResource) readActivatedLocales(respDiags *diag.Diagnostics, data *ChannelResourceModel) {
+	locales, err := r.localeClient.GetActivatedLocales()
+	if err != nil {
+		respDiags.AddError(
+			"Error while reading activated locales",
+			"An unexpected error occurred when reading activated locales. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	codes := make([]string, len(locales))
+	for i, v := range locales {
+		codes[i] = v.Code
+	}
+
+	data.ActivatedLocales = typesx.SetValue(respDiags, codes)
+}
+
 func (r *ChannelResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *ChannelResourceModel) *goakeneo.Channel {
 	a := goakeneo.Channel{
 		Code: data.Code.ValueString(),
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
-	}
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
 
-	if !(data.Locales.IsNull() || data.Locales.IsUnknown()) {
-		elements := make([]types.String, 0, len(data.Locales.Elements()))
-		diags.Append(data.Locales.ElementsAs(ctx, &elements, false)...)
-		locales := make([]string, len(elements))
-		for i, ext := range elements {
-			locales[i] = ext.ValueString()
-		}
-		a.Locales = locales
-	}
+	a.Locales = typesx.SetElements[string](ctx, diags, data.Locales)
 
-	if !(data.Currencies.IsNull() || data.Currencies.IsUnknown()) {
-		elements := make([]types.String, 0, len(data.Currencies.Elements()))
-		diags.Append(data.Currencies.ElementsAs(ctx, &elements, false)...)
-		currencies := make([]string, len(elements))
-		for i, ext := range elements {
-			currencies[i] = ext.ValueString()
-		}
-		a.Currencies = currencies
-	}
+	a.Currencies = typesx.SetElements[string](ctx, diags, data.Currencies)
 
 	if !(data.CategoryTree.IsNull() || data.CategoryTree.IsUnknown()) {
 		a.CategoryTree = data.CategoryTree.ValueString()
 	}
 
-	if !(data.ConversionUnits.IsNull() || data.ConversionUnits.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.ConversionUnits.Elements()))
-		diags.Append(data.ConversionUnits.ElementsAs(ctx, &elements, false)...)
-		units := make(map[string]string)
-		for code, unit := range elements {
-			units[code] = unit.ValueString()
-		}
-		a.ConversionUnits = units
-	}
+	a.ConversionUnits = typesx.MapElements[string](ctx, diags, data.ConversionUnits)
 
 	if diags.HasError() {
 		return nil
@@ -295,61 +740,13 @@ func (r *ChannelResource) mapToApiObject(ctx context.Context, diags *diag.Diagno
 func (r *ChannelResource) mapToTfObject(respDiags *diag.Diagnostics, data *ChannelResourceModel, apiData *goakeneo.Channel) {
 	data.Code = types.StringValue(apiData.Code)
 
-	if len(apiData.Labels) > 0 {
-		elements := make(map[string]attr.Value, len(apiData.Labels))
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, apiData.Labels)
 
-		for k, v := range apiData.Labels {
-			elements[k] = types.StringValue(v)
-		}
+	data.Locales = typesx.SetValue(respDiags, apiData.Locales)
 
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
-
-	if len(apiData.Locales) > 0 {
-		elements := make([]attr.Value, len(apiData.Locales))
-
-		for k, v := range apiData.Locales {
-			elements[k] = types.StringValue(v)
-		}
-
-		listVal, diags := types.ListValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Locales = listVal
-	}
-
-	if len(apiData.Currencies) > 0 {
-		elements := make([]attr.Value, len(apiData.Currencies))
-
-		for k, v := range apiData.Currencies {
-			elements[k] = types.StringValue(v)
-		}
-
-		listVal, diags := types.ListValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Currencies = listVal
-	}
+	data.Currencies = typesx.SetValue(respDiags, apiData.Currencies)
 
 	data.CategoryTree = types.StringValue(apiData.CategoryTree)
 
-	if len(apiData.ConversionUnits) > 0 {
-		elements := make(map[string]attr.Value, len(apiData.ConversionUnits))
-
-		for k, v := range apiData.ConversionUnits {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
+	data.ConversionUnits = typesx.MapValue(respDiags, apiData.ConversionUnits)
 }
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccChannelResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_channel" "test" {
+  code          = "print"
+  labels        = { en_US = "Print" }
+  locales       = ["en_US", "de_DE"]
+  currencies    = ["EUR"]
+  category_tree = "master"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_channel.test", "code", "print"),
+					resource.TestCheckResourceAttr("akeneo_channel.test", "locales.#", "2"),
+					resource.TestCheckResourceAttr("akeneo_channel.test", "currencies.#", "1"),
+					resource.TestCheckResourceAttr("akeneo_channel.test", "activated_locales.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_channel.test",
+				ImportState:                          true,
+				ImportStateId:                        "print",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_attribute" "weight" {
+  code                = "weight"
+  type                = "pim_catalog_metric"
+  group               = "other"
+  metric_family       = "Weight"
+  default_metric_unit = "KILOGRAM"
+  decimals_allowed    = true
+  negative_allowed    = false
+}
+
+resource "akeneo_channel" "test" {
+  code          = "print"
+  labels        = { en_US = "Print" }
+  locales       = ["en_US", "fr_FR"]
+  currencies    = ["EUR", "USD"]
+  category_tree = "master"
+
+  conversion_units = {
+    (akeneo_attribute.weight.code) = "GRAM"
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_channel.test", "currencies.#", "2"),
+					resource.TestCheckTypeSetElemAttr("akeneo_channel.test", "activated_locales.*", "fr_FR"),
+					resource.TestCheckResourceAttr("akeneo_channel.test", "conversion_units.weight", "GRAM"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute.weight", "akeneo_channel.test"),
+			},
+		},
+	})
+}
+
+func TestLocaleUsages(t *testing.T) {
+	localizable := true
+	attributes := []goakeneo.Attribute{
+		{Code: "name", Localizable: &localizable, Labels: map[string]string{"en_US": "Name", "fr_FR": "Nom"}},
+		{Code: "description", Localizable: &localizable},
+		{Code: "sku"},
+		{Code: "care", AvailableLocales: []string{"de_DE"}},
+	}
+	families := []goakeneo.Family{
+		{
+			Code:   "shoes",
+			Labels: map[string]string{"de_DE": "Schuhe"},
+			AttributeRequirements: map[string][]string{
+				"ecommerce": {"sku", "name", "description"},
+				"print":     {"sku"},
+			},
+		},
+		{
+			Code:                  "socks",
+			AttributeRequirements: map[string][]string{"ecommerce": {"sku"}},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		channel     string
+		deactivated []string
+		expected    []string
+	}{
+		{
+			name:        "labels and available locales",
+			channel:     "print",
+			deactivated: []string{"de_DE", "fr_FR"},
+			expected: []string{
+				"Locale de_DE is not used by any other channel and will be deactivated, but it is still used in: " +
+					"available_locales of attribute care, labels of family shoes. " +
+					"Values and labels in a deactivated locale are ignored by Akeneo.",
+				"Locale fr_FR is not used by any other channel and will be deactivated, but it is still used in: " +
+					"labels of attribute name. " +
+					"Values and labels in a deactivated locale are ignored by Akeneo.",
+			},
+		},
+		{
+			name:        "family attribute requirements",
+			channel:     "ecommerce",
+			deactivated: []string{"it_IT"},
+			expected: []string{
+				"Locale it_IT is not used by any other channel and will be deactivated, but it is still used in: " +
+					"attribute_requirements of family shoes for channel ecommerce with the localizable attributes description, name. " +
+					"Values and labels in a deactivated locale are ignored by Akeneo.",
+			},
+		},
+		{
+			name:        "unused locale",
+			channel:     "print",
+			deactivated: []string{"it_IT"},
+			expected:    []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			deactivated := make(map[string]bool, len(c.deactivated))
+			for _, locale := range c.deactivated {
+				deactivated[locale] = true
+			}
+
+			usages := localeUsages(c.channel, deactivated, attributes, families)
+			if !reflect.DeepEqual(usages, c.expected) {
+				t.Errorf("expected %q, got %q", c.expected, usages)
+			}
+		})
+	}
+}
+
+func TestChannelResourceUpgradeState_v0(t *testing.T) {
+	var data ChannelResourceModel
+	testUpgradeState(t, NewChannelResource(), 0, `{
+  "code": "ecommerce",
+  "locales": ["en_US"],
+  "currencies": ["EUR", "USD"],
+  "category_tree": "master",
+  "conversion_units": {"weight": ["GRAM", "KILOGRAM"], "length": []}
+}`, &data)
+
+	if !data.Labels.IsNull() || !data.EffectiveLabels.IsNull() || !data.Label.IsNull() {
+		t.Errorf("expected null labels, effective_labels and label, got %s, %s and %s", data.Labels, data.EffectiveLabels, data.Label)
+	}
+	if expected := testStringSet("EUR", "USD"); !data.Currencies.Equal(expected) {
+		t.Errorf("expected currencies %s, got %s", expected, data.Currencies)
+	}
+	if expected := types.MapValueMust(types.StringType, map[string]attr.Value{"weight": types.StringValue("GRAM")}); !data.ConversionUnits.Equal(expected) {
+		t.Errorf("expected conversion_units %s, got %s", expected, data.ConversionUnits)
+	}
+}
+
+func TestChannelResourceUpgradeState_v1(t *testing.T) {
+	var data ChannelResourceModel
+	testUpgradeState(t, NewChannelResource(), 1, `{
+  "code": "ecommerce",
+  "labels": {"en_US": "Ecommerce"},
+  "locales": ["en_US", "de_DE", "en_US"],
+  "currencies": ["EUR"],
+  "category_tree": "master",
+  "conversion_units": {"weight": "GRAM"},
+  "activated_locales": ["de_DE", "en_US"]
+}`, &data)
+
+	labels := testLabelsMap(t, map[string]string{"en_US": "Ecommerce"})
+	if !data.Labels.Equal(labels) || !data.EffectiveLabels.Equal(labels) || !data.Label.IsNull() {
+		t.Errorf("expected labels and effective_labels %s without label, got %s, %s and %s", labels, data.Labels, data.EffectiveLabels, data.Label)
+	}
+	if expected := testStringSet("en_US", "de_DE"); !data.Locales.Equal(expected) {
+		t.Errorf("expected locales %s, got %s", expected, data.Locales)
+	}
+	if expected := testStringSet("EUR"); !data.Currencies.Equal(expected) {
+		t.Errorf("expected currencies %s, got %s", expected, data.Currencies)
+	}
+	if expected := types.MapValueMust(types.StringType, map[string]attr.Value{"weight": types.StringValue("GRAM")}); !data.ConversionUnits.Equal(expected) {
+		t.Errorf("expected conversion_units %s, got %s", expected, data.ConversionUnits)
+	}
+}
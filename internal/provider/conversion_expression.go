@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+)
+
+const conversionVariable = "x"
+
+var (
+	conversionOperatorSymbols = map[string]string{
+		"+": "add",
+		"-": "sub",
+		"*": "mul",
+		"/": "div",
+	}
+	conversionSymbolOperators = map[string]string{
+		"add": "+",
+		"sub": "-",
+		"mul": "*",
+		"div": "/",
+	}
+)
+
+// parseConversionFormula turns a formula such as `x * 1000 + 32` into the list
+// of operations Akeneo uses to convert a value from the standard unit.
+//
+// Akeneo applies the operations one after another, so the formula is read from
+// left to right. Parentheses may group the left-hand side, e.g. `(x + 32) * 2`,
+// and a multiplication or division following an addition or subtraction must be
+// grouped that way, as `x + 32 * 2` would read differently in plain arithmetic.
+func parseConversionFormula(formula string) ([]akeneox.MeasurementUnitConversion, error) {
+	tokens, err := tokenizeConversionFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	p := conversionFormulaParser{tokens: tokens}
+	operations, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	// The bare variable is the standard unit itself.
+	if len(operations) == 0 {
+		operations = standardUnitConversion()
+	}
+
+	return operations, nil
+}
+
+// formatConversionFormula turns Akeneo conversion operations back into a formula
+// accepted by parseConversionFormula. The standard unit conversion is formatted
+// as the bare variable.
+func formatConversionFormula(operations []akeneox.MeasurementUnitConversion) (string, error) {
+	if isIdentityConversion(operations) {
+		return conversionVariable, nil
+	}
+
+	formula := conversionVariable
+	additive := false
+	for i, operation := range operations {
+		symbol, ok := conversionSymbolOperators[operation.Operator]
+		if !ok {
+			return "", fmt.Errorf("operation %d: unknown operator %q", i+1, operation.Operator)
+		}
+		if !stringvalidatorx.IsValidDecimal(operation.Value) {
+			return "", fmt.Errorf("operation %d: value %q is not a decimal number", i+1, operation.Value)
+		}
+
+		multiplicative := symbol == "*" || symbol == "/"
+		if multiplicative && additive {
+			formula = "(" + formula + ")"
+		}
+		formula += " " + symbol + " " + operation.Value
+		additive = !multiplicative
+	}
+
+	return formula, nil
+}
+
+// standardUnitConversion returns the conversion Akeneo requires for the standard unit.
+func standardUnitConversion() []akeneox.MeasurementUnitConversion {
+	return []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1"}}
+}
+
+// isIdentityConversion reports whether the operations leave the value unchanged
+// in the form Akeneo expects for the standard unit, a single `mul 1`.
+func isIdentityConversion(operations []akeneox.MeasurementUnitConversion) bool {
+	if len(operations) != 1 || operations[0].Operator != "mul" {
+		return false
+	}
+
+	value, ok := new(big.Rat).SetString(operations[0].Value)
+	return ok && value.Cmp(big.NewRat(1, 1)) == 0
+}
+
+// isZeroDecimal reports whether value is a decimal number equal to zero.
+func isZeroDecimal(value string) bool {
+	r, ok := new(big.Rat).SetString(value)
+	return ok && r.Sign() == 0
+}
+
+func tokenizeConversionFormula(formula string) ([]string, error) {
+	var tokens []string
+	runes := []rune(formula)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.ContainsRune("+-*/()", c):
+			tokens = append(tokens, string(c))
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		case unicode.IsLetter(c):
+			start := i
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("formula is empty")
+	}
+
+	return tokens, nil
+}
+
+type conversionFormulaParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *conversionFormulaParser) next() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	return token, true
+}
+
+func (p *conversionFormulaParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// parseExpression reads `operand (operator number)*` where the operand is the
+// variable or a parenthesized expression.
+func (p *conversionFormulaParser) parseExpression() ([]akeneox.MeasurementUnitConversion, error) {
+	var operations []akeneox.MeasurementUnitConversion
+
+	token, ok := p.next()
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("expected %q", conversionVariable)
+	case token == "(":
+		inner, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if token, _ := p.next(); token != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		operations = inner
+	case token != conversionVariable:
+		return nil, fmt.Errorf("expected %q, got %q, the formula must start with the value in the standard unit", conversionVariable, token)
+	}
+
+	// The operand is a single term, either the variable or a group.
+	additive := false
+	for p.peek() != "" && p.peek() != ")" {
+		symbol, _ := p.next()
+		operator, ok := conversionOperatorSymbols[symbol]
+		if !ok {
+			return nil, fmt.Errorf("expected an operator, got %q", symbol)
+		}
+
+		multiplicative := operator == "mul" || operator == "div"
+		if multiplicative && additive {
+			return nil, fmt.Errorf("operations are applied from left to right, wrap the left-hand side of %q in parentheses", symbol)
+		}
+
+		value, ok := p.next()
+		if !ok {
+			return nil, fmt.Errorf("expected a number after %q", symbol)
+		}
+		if value == "-" {
+			// Negative number, e.g. `x * -1`.
+			number, _ := p.next()
+			value += number
+		}
+		if !stringvalidatorx.IsValidDecimal(value) {
+			return nil, fmt.Errorf("expected a number after %q, got %q", symbol, value)
+		}
+
+		operations = append(operations, akeneox.MeasurementUnitConversion{
+			Operator: operator,
+			Value:    value,
+		})
+		additive = !multiplicative
+	}
+
+	return operations, nil
+}
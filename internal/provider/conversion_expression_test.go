@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+)
+
+func TestParseConversionFormula(t *testing.T) {
+	cases := []struct {
+		name      string
+		formula   string
+		expected  []akeneox.MeasurementUnitConversion
+		expectErr string
+	}{
+		{
+			name:     "variable",
+			formula:  "x",
+			expected: []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1"}},
+		},
+		{
+			name:     "parenthesized variable",
+			formula:  "(x)",
+			expected: []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1"}},
+		},
+		{
+			name:     "single operation",
+			formula:  "x * 1000",
+			expected: []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1000"}},
+		},
+		{
+			name:    "left to right",
+			formula: "x*1.8+32",
+			expected: []akeneox.MeasurementUnitConversion{
+				{Operator: "mul", Value: "1.8"},
+				{Operator: "add", Value: "32"},
+			},
+		},
+		{
+			name:    "grouped left-hand side",
+			formula: "(x - 32) / 1.8",
+			expected: []akeneox.MeasurementUnitConversion{
+				{Operator: "sub", Value: "32"},
+				{Operator: "div", Value: "1.8"},
+			},
+		},
+		{
+			name:    "nested groups",
+			formula: "((x + 1) * 2 - 3) / 4",
+			expected: []akeneox.MeasurementUnitConversion{
+				{Operator: "add", Value: "1"},
+				{Operator: "mul", Value: "2"},
+				{Operator: "sub", Value: "3"},
+				{Operator: "div", Value: "4"},
+			},
+		},
+		{
+			name:     "negative number",
+			formula:  "x * -1",
+			expected: []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "-1"}},
+		},
+		{
+			name:     "mul 1 is kept",
+			formula:  "x * 1",
+			expected: []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1"}},
+		},
+		{
+			name:      "empty",
+			formula:   "  ",
+			expectErr: "formula is empty",
+		},
+		{
+			name:      "number first",
+			formula:   "1000 * x",
+			expectErr: `expected "x", got "1000"`,
+		},
+		{
+			name:      "other variable",
+			formula:   "y * 2",
+			expectErr: `expected "x", got "y"`,
+		},
+		{
+			name:      "multiplication after addition",
+			formula:   "x + 32 * 2",
+			expectErr: `wrap the left-hand side of "*" in parentheses`,
+		},
+		{
+			name:      "missing number",
+			formula:   "x *",
+			expectErr: `expected a number after "*"`,
+		},
+		{
+			name:      "variable as operand",
+			formula:   "x * x",
+			expectErr: `expected a number after "*", got "x"`,
+		},
+		{
+			name:      "invalid number",
+			formula:   "x * 1.2.3",
+			expectErr: `expected a number after "*", got "1.2.3"`,
+		},
+		{
+			name:      "missing operator",
+			formula:   "x 2",
+			expectErr: `expected an operator, got "2"`,
+		},
+		{
+			name:      "missing closing parenthesis",
+			formula:   "(x + 1",
+			expectErr: "missing closing parenthesis",
+		},
+		{
+			name:      "unexpected closing parenthesis",
+			formula:   "x + 1)",
+			expectErr: `unexpected ")"`,
+		},
+		{
+			name:      "unexpected character",
+			formula:   "x ^ 2",
+			expectErr: `unexpected character '^'`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			operations, err := parseConversionFormula(c.formula)
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error %q, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(operations, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, operations)
+			}
+		})
+	}
+}
+
+func TestFormatConversionFormula(t *testing.T) {
+	cases := []struct {
+		name       string
+		operations []akeneox.MeasurementUnitConversion
+		expected   string
+		expectErr  string
+	}{
+		{
+			name:       "standard unit",
+			operations: []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1"}},
+			expected:   "x",
+		},
+		{
+			name:       "standard unit with decimals",
+			operations: []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1.000"}},
+			expected:   "x",
+		},
+		{
+			name:       "div 1 is not the standard unit conversion",
+			operations: []akeneox.MeasurementUnitConversion{{Operator: "div", Value: "1"}},
+			expected:   "x / 1",
+		},
+		{
+			name: "mul 1 among other operations",
+			operations: []akeneox.MeasurementUnitConversion{
+				{Operator: "mul", Value: "1"},
+				{Operator: "add", Value: "2"},
+			},
+			expected: "x * 1 + 2",
+		},
+		{
+			name: "multiplication after addition",
+			operations: []akeneox.MeasurementUnitConversion{
+				{Operator: "sub", Value: "32"},
+				{Operator: "div", Value: "1.8"},
+			},
+			expected: "(x - 32) / 1.8",
+		},
+		{
+			name: "addition after multiplication",
+			operations: []akeneox.MeasurementUnitConversion{
+				{Operator: "mul", Value: "1.8"},
+				{Operator: "add", Value: "32"},
+			},
+			expected: "x * 1.8 + 32",
+		},
+		{
+			name: "division by zero is formatted",
+			operations: []akeneox.MeasurementUnitConversion{
+				{Operator: "div", Value: "0"},
+			},
+			expected: "x / 0",
+		},
+		{
+			name:       "unknown operator",
+			operations: []akeneox.MeasurementUnitConversion{{Operator: "pow", Value: "2"}},
+			expectErr:  `operation 1: unknown operator "pow"`,
+		},
+		{
+			name: "invalid value",
+			operations: []akeneox.MeasurementUnitConversion{
+				{Operator: "mul", Value: "2"},
+				{Operator: "add", Value: "abc"},
+			},
+			expectErr: `operation 2: value "abc" is not a decimal number`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			formula, err := formatConversionFormula(c.operations)
+			if c.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expectErr) {
+					t.Fatalf("expected error %q, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if formula != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, formula)
+			}
+		})
+	}
+}
+
+func TestConversionFormulaRoundTrip(t *testing.T) {
+	formulas := []string{
+		"x",
+		"x * 1000",
+		"x / 1000",
+		"x * 1.8 + 32",
+		"(x - 32) / 1.8",
+		"(x + 1) * 2 - 3",
+		"((x + 1) * 2 - 3) / 4",
+		"x * -1",
+		"x - 0.5 + 2",
+	}
+
+	for _, formula := range formulas {
+		t.Run(formula, func(t *testing.T) {
+			operations, err := parseConversionFormula(formula)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			formatted, err := formatConversionFormula(operations)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if formatted != formula {
+				t.Errorf("expected %s, got %s", formula, formatted)
+			}
+		})
+	}
+}
+
+func TestIsIdentityConversion(t *testing.T) {
+	cases := []struct {
+		name       string
+		operations []akeneox.MeasurementUnitConversion
+		expected   bool
+	}{
+		{"mul 1", []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1"}}, true},
+		{"mul 1.0", []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1.0"}}, true},
+		{"mul 2", []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "2"}}, false},
+		{"div 1", []akeneox.MeasurementUnitConversion{{Operator: "div", Value: "1"}}, false},
+		{"add 0", []akeneox.MeasurementUnitConversion{{Operator: "add", Value: "0"}}, false},
+		{"mul 1 twice", []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "1"}, {Operator: "mul", Value: "1"}}, false},
+		{"no operations", nil, false},
+		{"invalid value", []akeneox.MeasurementUnitConversion{{Operator: "mul", Value: "one"}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := isIdentityConversion(c.operations); actual != c.expected {
+				t.Errorf("expected %t, got %t", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsZeroDecimal(t *testing.T) {
+	cases := map[string]bool{
+		"0":     true,
+		"0.0":   true,
+		"-0":    true,
+		"0.001": false,
+		"1":     false,
+		"zero":  false,
+		"":      false,
+	}
+
+	for value, expected := range cases {
+		t.Run(value, func(t *testing.T) {
+			if actual := isZeroDecimal(value); actual != expected {
+				t.Errorf("expected %t, got %t", expected, actual)
+			}
+		})
+	}
+}
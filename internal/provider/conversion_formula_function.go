@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ConversionFormulaFunction{}
+
+func NewConversionFormulaFunction() function.Function {
+	return &ConversionFormulaFunction{}
+}
+
+// ConversionFormulaFunction turns Akeneo conversion operations into a readable formula.
+type ConversionFormulaFunction struct{}
+
+func (f *ConversionFormulaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "conversion_formula"
+}
+
+func (f *ConversionFormulaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Format measurement unit conversion operations as a formula",
+		Description: "Returns the formula of the conversion operations of a measurement unit, the reverse of conversion_operations. Example: provider::akeneo::conversion_formula(akeneo_measurement_family.temperature.units[1].convert_from_standard)",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "operations",
+				Description: "Conversion operations with operator and value",
+				ElementType: conversionOperationType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ConversionFormulaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var operations []MeasurementFamilyResourceUnitConversionModel
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &operations))
+
+	if resp.Error != nil {
+		return
+	}
+
+	conversions := make([]akeneox.MeasurementUnitConversion, len(operations))
+	for i, operation := range operations {
+		conversions[i] = akeneox.MeasurementUnitConversion{
+			Operator: operation.Operator.ValueString(),
+			Value:    operation.Value.ValueString(),
+		}
+	}
+
+	formula, err := formatConversionFormula(conversions)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid conversion operations: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, formula))
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var conversionOperationType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"operator": types.StringType,
+		"value":    types.StringType,
+	},
+}
+
+var _ function.Function = &ConversionOperationsFunction{}
+
+func NewConversionOperationsFunction() function.Function {
+	return &ConversionOperationsFunction{}
+}
+
+// ConversionOperationsFunction turns a conversion formula into Akeneo conversion operations.
+type ConversionOperationsFunction struct{}
+
+func (f *ConversionOperationsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "conversion_operations"
+}
+
+func (f *ConversionOperationsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build measurement unit conversion operations from a formula",
+		Description: "Returns the operations of a formula converting the value x in the standard unit, usable as convert_from_standard of a measurement unit. Operations are applied from left to right, so a multiplication or division following an addition or subtraction requires parentheses. The formula x alone is the standard unit conversion mul 1. Example: provider::akeneo::conversion_operations(\"x * 1.8 + 32\")",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "formula",
+				Description: "Formula converting the value x in the standard unit, e.g. (x + 32) * 2",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: conversionOperationType,
+		},
+	}
+}
+
+func (f *ConversionOperationsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var formula string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &formula))
+
+	if resp.Error != nil {
+		return
+	}
+
+	operations, err := parseConversionFormula(formula)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid conversion formula: "+err.Error())
+		return
+	}
+
+	result := make([]MeasurementFamilyResourceUnitConversionModel, len(operations))
+	for i, operation := range operations {
+		result[i] = MeasurementFamilyResourceUnitConversionModel{
+			Operator: types.StringValue(operation.Operator),
+			Value:    types.StringValue(operation.Value),
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
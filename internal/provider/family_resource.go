@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	goakeneo "github.com/ezifyio/go-akeneo"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
@@ -12,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -20,6 +23,8 @@ import (
 var _ resource.Resource = &FamilyResource{}
 var _ resource.ResourceWithImportState = &FamilyResource{}
 var _ resource.ResourceWithConfigure = &FamilyResource{}
+var _ resource.ResourceWithModifyPlan = &FamilyResource{}
+var _ resource.ResourceWithUpgradeState = &FamilyResource{}
 
 func NewFamilyResource() resource.Resource {
 	return &FamilyResource{}
@@ -28,16 +33,20 @@ func NewFamilyResource() resource.Resource {
 // FamilyResource defines the resource implementation.
 type FamilyResource struct {
 	client *akeneox.FamilyService
+	labels labelDefaults
 }
 
 // FamilyResourceModel describes the resource data model.
 type FamilyResourceModel struct {
 	Code                  types.String `tfsdk:"code"`
 	Labels                types.Map    `tfsdk:"labels"`
-	Attributes            types.List   `tfsdk:"attributes"`
+	Label                 types.String `tfsdk:"label"`
+	EffectiveLabels       types.Map    `tfsdk:"effective_labels"`
+	Attributes            types.Set    `tfsdk:"attributes"`
 	AttributeAsLabel      types.String `tfsdk:"attribute_as_label"`
 	AttributeAsImage      types.String `tfsdk:"attribute_as_image"`
 	AttributeRequirements types.Map    `tfsdk:"attribute_requirements"`
+	AdoptExisting         types.Bool   `tfsdk:"adopt_existing"`
 }
 
 func (r *FamilyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,6 +56,7 @@ func (r *FamilyResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *FamilyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Akeneo family resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"code": schema.StringAttribute{
@@ -61,14 +71,20 @@ func (r *FamilyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
-			"attributes": schema.ListAttribute{
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
+			"attributes": schema.SetAttribute{
 				Description: "Attributes assigned to the family",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
 			"attribute_as_label": schema.StringAttribute{
-				Description: "Attribute used as product label for the family",
+				Description: "Attribute used as product label for the family, Akeneo uses the identifier attribute when it is not set",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"attribute_as_image": schema.StringAttribute{
 				Description: "Attribute used as product image for the family",
@@ -77,10 +93,101 @@ func (r *FamilyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"attribute_requirements": schema.MapAttribute{
 				Description: "Attribute codes of the family that are required for the completeness calculation for each channel.",
 				Optional:    true,
-				ElementType: types.ListType{
+				ElementType: types.SetType{
 					ElemType: types.StringType,
 				},
 			},
+			"adopt_existing": adoptExistingAttribute(),
+		},
+	}
+}
+
+func (r *FamilyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored the attributes and attribute requirements as ordered lists.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"code": schema.StringAttribute{
+						Required: true,
+					},
+					"labels": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"attributes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"attribute_as_label": schema.StringAttribute{
+						Optional: true,
+					},
+					"attribute_as_image": schema.StringAttribute{
+						Optional: true,
+					},
+					"attribute_requirements": schema.MapAttribute{
+						Optional: true,
+						ElementType: types.ListType{
+							ElemType: types.StringType,
+						},
+					},
+					"adopt_existing": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					Code                  types.String `tfsdk:"code"`
+					Labels                types.Map    `tfsdk:"labels"`
+					Attributes            types.List   `tfsdk:"attributes"`
+					AttributeAsLabel      types.String `tfsdk:"attribute_as_label"`
+					AttributeAsImage      types.String `tfsdk:"attribute_as_image"`
+					AttributeRequirements types.Map    `tfsdk:"attribute_requirements"`
+					AdoptExisting         types.Bool   `tfsdk:"adopt_existing"`
+				}
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := FamilyResourceModel{
+					Code:                  prior.Code,
+					Labels:                prior.Labels,
+					Label:                 types.StringNull(),
+					EffectiveLabels:       prior.Labels,
+					AttributeAsLabel:      prior.AttributeAsLabel,
+					AttributeAsImage:      prior.AttributeAsImage,
+					AttributeRequirements: types.MapNull(types.SetType{ElemType: types.StringType}),
+					AdoptExisting:         prior.AdoptExisting,
+				}
+
+				attributes, diags := stringListToSet(prior.Attributes)
+				resp.Diagnostics.Append(diags...)
+				data.Attributes = attributes
+
+				if !prior.AttributeRequirements.IsNull() {
+					elements := make(map[string]attr.Value, len(prior.AttributeRequirements.Elements()))
+					for channel, v := range prior.AttributeRequirements.Elements() {
+						list, ok := v.(types.List)
+						if !ok {
+							continue
+						}
+						setVal, diags := stringListToSet(list)
+						resp.Diagnostics.Append(diags...)
+						elements[channel] = setVal
+					}
+
+					mapVal, diags := types.MapValue(types.SetType{ElemType: types.StringType}, elements)
+					resp.Diagnostics.Append(diags...)
+					data.AttributeRequirements = mapVal
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
 		},
 	}
 }
@@ -109,9 +216,14 @@ func (r *FamilyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewFamilyClient(data.Client)
 }
 
+func (r *FamilyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+}
+
 func (r *FamilyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data FamilyResourceModel
 
@@ -128,7 +240,16 @@ func (r *FamilyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	err := r.client.CreateFamily(*apiData)
+	_, err := r.client.GetFamily(apiData.Code)
+	switch {
+	case err == nil && !data.AdoptExisting.ValueBool():
+		addAlreadyExistsError(&resp.Diagnostics, "family", apiData.Code)
+		return
+	case err == nil:
+		_, err = r.client.UpdateFamily(*apiData)
+	case akeneox.IsNotFound(err):
+		err = r.client.CreateFamily(*apiData)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while creating a family",
@@ -138,10 +259,21 @@ func (r *FamilyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	if resp.Diagnostics.HasError() {
+	// Read the family back for the attribute as label Akeneo sets by default.
+	familyData, err := r.client.GetFamily(data.Code.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading a family",
+			"An unexpected error occurred when reading created family. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
 		return
 	}
 
+	if data.AttributeAsLabel.IsUnknown() {
+		data.AttributeAsLabel = types.StringValue(familyData.AttributeAsLabel)
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -156,7 +288,7 @@ func (r *FamilyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	apiData, err := r.client.GetFamily(data.Code.ValueString(), nil)
+	apiData, err := r.client.GetFamily(data.Code.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while creating a family",
@@ -171,6 +303,7 @@ func (r *FamilyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	r.mapToTfObject(&resp.Diagnostics, &data, apiData)
+	data.AdoptExisting = adoptExistingValue(data.AdoptExisting)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -196,6 +329,8 @@ func (r *FamilyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
 	_, err := r.client.UpdateFamily(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -239,25 +374,9 @@ func (r *FamilyResource) mapToApiObject(ctx context.Context, diags *diag.Diagnos
 		Code: data.Code.ValueString(),
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
-	}
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
 
-	if !(data.Attributes.IsNull() || data.Attributes.IsUnknown()) {
-		elements := make([]types.String, 0, len(data.Attributes.Elements()))
-		diags.Append(data.Attributes.ElementsAs(ctx, &elements, false)...)
-		attrs := make([]string, len(elements))
-		for i, ext := range elements {
-			attrs[i] = ext.ValueString()
-		}
-		a.Attributes = attrs
-	}
+	a.Attributes = typesx.SetElements[string](ctx, diags, data.Attributes)
 
 	if !(data.AttributeAsLabel.IsNull() || data.AttributeAsLabel.IsUnknown()) {
 		a.AttributeAsLabel = data.AttributeAsLabel.ValueString()
@@ -267,21 +386,7 @@ func (r *FamilyResource) mapToApiObject(ctx context.Context, diags *diag.Diagnos
 		a.AttributeAsImage = data.AttributeAsImage.ValueString()
 	}
 
-	if !(data.AttributeRequirements.IsNull() || data.AttributeRequirements.IsUnknown()) {
-		elements := make(map[string]types.List, len(data.AttributeRequirements.Elements()))
-		diags.Append(data.AttributeRequirements.ElementsAs(ctx, &elements, false)...)
-		reqs := make(map[string][]string)
-		for channel, attrs := range elements {
-			innerElements := make([]types.String, 0, len(attrs.Elements()))
-			diags.Append(attrs.ElementsAs(ctx, &innerElements, false)...)
-			reqAttrs := make([]string, len(innerElements))
-			for i, a := range innerElements {
-				reqAttrs[i] = a.ValueString()
-			}
-			reqs[channel] = reqAttrs
-		}
-		a.AttributeRequirements = reqs
-	}
+	a.AttributeRequirements = typesx.MapElements[[]string](ctx, diags, data.AttributeRequirements)
 
 	if diags.HasError() {
 		return nil
@@ -290,60 +395,21 @@ func (r *FamilyResource) mapToApiObject(ctx context.Context, diags *diag.Diagnos
 	return &a
 }
 
+// mapToTfObject maps the family into the model, empty values are mapped to null
+// as Akeneo returns them for every property which is not set.
 func (r *FamilyResource) mapToTfObject(respDiags *diag.Diagnostics, data *FamilyResourceModel, apiData *goakeneo.Family) {
-	// todo map only non empties
 	data.Code = types.StringValue(apiData.Code)
 
-	if len(apiData.Labels) > 0 {
-		elements := make(map[string]attr.Value, len(apiData.Labels))
-
-		for k, v := range apiData.Labels {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, apiData.Labels)
 
-	if len(apiData.Attributes) > 0 {
-		elements := make([]attr.Value, len(apiData.Attributes))
+	data.Attributes = typesx.SetValue(respDiags, apiData.Attributes)
 
-		for k, v := range apiData.Attributes {
-			elements[k] = types.StringValue(v)
-		}
+	data.AttributeAsLabel = types.StringValue(apiData.AttributeAsLabel)
 
-		listVal, diags := types.ListValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Attributes = listVal
+	data.AttributeAsImage = types.StringNull()
+	if apiData.AttributeAsImage != "" {
+		data.AttributeAsImage = types.StringValue(apiData.AttributeAsImage)
 	}
 
-	data.AttributeAsLabel = types.StringValue(apiData.AttributeAsLabel)
-	data.AttributeAsImage = types.StringValue(apiData.AttributeAsImage)
-
-	if len(apiData.AttributeRequirements) > 0 {
-		elements := make(map[string]attr.Value, len(apiData.AttributeRequirements))
-
-		for k, v := range apiData.AttributeRequirements {
-			innerElements := make([]attr.Value, len(v))
-			for i, a := range v {
-				innerElements[i] = types.StringValue(a)
-			}
-			listVal, diags := types.ListValue(types.StringType, innerElements)
-			if diags.HasError() {
-				respDiags.Append(diags...)
-			}
-			elements[k] = listVal
-		}
-
-		mapVal, diags := types.MapValue(types.ListType{ElemType: types.StringType}, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.AttributeRequirements = mapVal
-	}
+	data.AttributeRequirements = typesx.SetMapValue(respDiags, apiData.AttributeRequirements)
 }
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccFamilyAttributesConfig = `
+resource "akeneo_attribute" "name" {
+  code  = "name"
+  type  = "pim_catalog_text"
+  group = "other"
+}
+
+resource "akeneo_attribute" "description" {
+  code  = "description"
+  type  = "pim_catalog_textarea"
+  group = "other"
+}
+`
+
+func TestAccFamilyResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccFamilyAttributesConfig+`
+resource "akeneo_family" "test" {
+  code               = "shoes"
+  labels             = { en_US = "Shoes" }
+  attributes         = ["sku", akeneo_attribute.name.code]
+  attribute_as_label = akeneo_attribute.name.code
+
+  attribute_requirements = {
+    ecommerce = ["sku", akeneo_attribute.name.code]
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_family.test", "code", "shoes"),
+					resource.TestCheckResourceAttr("akeneo_family.test", "attributes.#", "2"),
+					resource.TestCheckResourceAttr("akeneo_family.test", "attribute_as_label", "name"),
+					resource.TestCheckResourceAttr("akeneo_family.test", "attribute_requirements.ecommerce.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_family.test",
+				ImportState:                          true,
+				ImportStateId:                        "shoes",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+				ImportStateVerifyIgnore:              []string{"adopt_existing"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccFamilyAttributesConfig+`
+resource "akeneo_family" "test" {
+  code               = "shoes"
+  labels             = { en_US = "Shoes" }
+  attributes         = ["sku", akeneo_attribute.name.code, akeneo_attribute.description.code]
+  attribute_as_label = akeneo_attribute.name.code
+
+  attribute_requirements = {
+    ecommerce = ["sku"]
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_family.test", "attributes.#", "3"),
+					resource.TestCheckResourceAttr("akeneo_family.test", "attribute_requirements.ecommerce.#", "1"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute.name", "akeneo_attribute.description", "akeneo_family.test"),
+			},
+		},
+	})
+}
+
+func TestFamilyResourceUpgradeState_v0(t *testing.T) {
+	var data FamilyResourceModel
+	testUpgradeState(t, NewFamilyResource(), 0, `{
+  "code": "shoes",
+  "attributes": ["sku", "name", "sku"],
+  "attribute_as_label": "name",
+  "attribute_requirements": {"ecommerce": ["sku", "name"]},
+  "adopt_existing": true
+}`, &data)
+
+	if !data.Labels.IsNull() || !data.EffectiveLabels.IsNull() || !data.Label.IsNull() {
+		t.Errorf("expected null labels, effective_labels and label, got %s, %s and %s", data.Labels, data.EffectiveLabels, data.Label)
+	}
+	if expected := testStringSet("sku", "name"); !data.Attributes.Equal(expected) {
+		t.Errorf("expected attributes %s, got %s", expected, data.Attributes)
+	}
+	requirements := types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+		"ecommerce": testStringSet("name", "sku"),
+	})
+	if !data.AttributeRequirements.Equal(requirements) {
+		t.Errorf("expected attribute_requirements %s, got %s", requirements, data.AttributeRequirements)
+	}
+	if !data.AdoptExisting.ValueBool() {
+		t.Errorf("expected adopt_existing to be kept, got %s", data.AdoptExisting)
+	}
+}
@@ -4,23 +4,38 @@ import (
 	"context"
 	"fmt"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	goakeneo "github.com/ezifyio/go-akeneo"
-	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+	"strconv"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FamilyVariantResource{}
 var _ resource.ResourceWithImportState = &FamilyVariantResource{}
 var _ resource.ResourceWithConfigure = &FamilyVariantResource{}
+var _ resource.ResourceWithModifyPlan = &FamilyVariantResource{}
+var _ resource.ResourceWithUpgradeState = &FamilyVariantResource{}
+
+const maxVariantAxesPerLevel = 5
+
+// variantAxisAttributeTypes are the attribute types Akeneo accepts as variant axes.
+var variantAxisAttributeTypes = map[string]bool{
+	"pim_catalog_simpleselect":        true,
+	"akeneo_reference_entity":         true,
+	"pim_catalog_metric":              true,
+	"pim_catalog_boolean":             true,
+	"pim_reference_data_simpleselect": true,
+}
 
 func NewFamilyVariantResource() resource.Resource {
 	return &FamilyVariantResource{}
@@ -28,21 +43,26 @@ func NewFamilyVariantResource() resource.Resource {
 
 // FamilyVariantResource defines the resource implementation.
 type FamilyVariantResource struct {
-	client *akeneox.FamilyService
+	client          *akeneox.FamilyService
+	attributeClient *akeneox.AttributeService
+	catalog         *akeneox.CatalogSnapshot
+	labels          labelDefaults
 }
 
 type VariantAttributeSetModel struct {
-	Level      types.Int64 `tfsdk:"level"`
-	Axes       types.List  `tfsdk:"axes"`
-	Attributes types.List  `tfsdk:"attributes"`
+	Axes       types.List `tfsdk:"axes"`
+	Attributes types.Set  `tfsdk:"attributes"`
 }
 
 // FamilyVariantResourceModel describes the resource data model.
 type FamilyVariantResourceModel struct {
-	FamilyCode           types.String               `tfsdk:"family_code"`
-	Code                 types.String               `tfsdk:"code"`
-	Labels               types.Map                  `tfsdk:"labels"`
-	VariantAttributeSets []VariantAttributeSetModel `tfsdk:"variant_attribute_sets"`
+	FamilyCode           types.String                        `tfsdk:"family_code"`
+	Code                 types.String                        `tfsdk:"code"`
+	Labels               types.Map                           `tfsdk:"labels"`
+	Label                types.String                        `tfsdk:"label"`
+	EffectiveLabels      types.Map                           `tfsdk:"effective_labels"`
+	VariantAttributeSets map[string]VariantAttributeSetModel `tfsdk:"variant_attribute_sets"`
+	AdoptExisting        types.Bool                          `tfsdk:"adopt_existing"`
 }
 
 func (r *FamilyVariantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +72,7 @@ func (r *FamilyVariantResource) Metadata(ctx context.Context, req resource.Metad
 func (r *FamilyVariantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Akeneo family variant resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"family_code": schema.StringAttribute{
@@ -70,31 +91,111 @@ func (r *FamilyVariantResource) Schema(ctx context.Context, req resource.SchemaR
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
-			"variant_attribute_sets": schema.ListNestedAttribute{
-				Description: "Attribute distributions according to the enrichment level.",
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
+			"variant_attribute_sets": schema.MapNestedAttribute{
+				Description: "Attribute distributions keyed by the enrichment level, 1 for the sub product models or products and 2 for the products of a two level variant.",
 				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf("1", "2")),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"level": schema.Int64Attribute{
-							Description: "Enrichment level",
-							Required:    true,
-							Validators: []validator.Int64{
-								int64validator.Between(1, 2147483647),
-							},
-						},
 						"axes": schema.ListAttribute{
-							Description: "Codes of attributes used as variant axes",
+							Description: "Codes of attributes used as variant axes, at most 5 per level. Axes must be non-localizable and non-scopable family attributes of type simple select, reference entity single link, metric, yes/no or reference data simple select.",
 							ElementType: types.StringType,
 							Optional:    true,
 						},
-						"attributes": schema.ListAttribute{
-							Description: "Codes of attributes bind to this enrichment level",
+						"attributes": schema.SetAttribute{
+							Description: "Codes of attributes bind to this enrichment level. Akeneo adds the axes and the identifier attribute implicitly, they only need to be listed here to be managed explicitly.",
 							ElementType: types.StringType,
 							Optional:    true,
 						},
 					},
 				},
 			},
+			"adopt_existing": adoptExistingAttribute(),
+		},
+	}
+}
+
+func (r *FamilyVariantResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored the variant attribute sets as an ordered list.
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"family_code": schema.StringAttribute{
+						Required: true,
+					},
+					"code": schema.StringAttribute{
+						Required: true,
+					},
+					"labels": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"variant_attribute_sets": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"level": schema.Int64Attribute{
+									Required: true,
+								},
+								"axes": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+								},
+								"attributes": schema.ListAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					FamilyCode           types.String `tfsdk:"family_code"`
+					Code                 types.String `tfsdk:"code"`
+					Labels               types.Map    `tfsdk:"labels"`
+					VariantAttributeSets []struct {
+						Level      types.Int64 `tfsdk:"level"`
+						Axes       types.List  `tfsdk:"axes"`
+						Attributes types.List  `tfsdk:"attributes"`
+					} `tfsdk:"variant_attribute_sets"`
+				}
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := FamilyVariantResourceModel{
+					FamilyCode:      prior.FamilyCode,
+					Code:            prior.Code,
+					Labels:          prior.Labels,
+					Label:           types.StringNull(),
+					EffectiveLabels: prior.Labels,
+				}
+
+				if prior.VariantAttributeSets != nil {
+					data.VariantAttributeSets = make(map[string]VariantAttributeSetModel, len(prior.VariantAttributeSets))
+					for _, set := range prior.VariantAttributeSets {
+						attributes, diags := stringListToSet(set.Attributes)
+						resp.Diagnostics.Append(diags...)
+
+						data.VariantAttributeSets[strconv.FormatInt(set.Level.ValueInt64(), 10)] = VariantAttributeSetModel{
+							Axes:       set.Axes,
+							Attributes: attributes,
+						}
+					}
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
 		},
 	}
 }
@@ -123,7 +224,36 @@ func (r *FamilyVariantResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
+	r.labels = newLabelDefaults(data)
 	r.client = akeneox.NewFamilyClient(data.Client)
+	r.attributeClient = akeneox.NewAttributeClient(data.Client)
+	r.catalog = data.Catalog
+}
+
+func (r *FamilyVariantResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+
+	// Nothing to validate on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data FamilyVariantResourceModel
+	var sets types.Map
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("variant_attribute_sets"), &sets)...)
+
+	if resp.Diagnostics.HasError() || sets.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validateAxes(ctx, &resp.Diagnostics, &data)
 }
 
 func (r *FamilyVariantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -142,7 +272,16 @@ func (r *FamilyVariantResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	err := r.client.UpdateOrCreate(data.FamilyCode.ValueString(), data.Code.ValueString(), *apiData)
+	_, err := r.client.GetFamilyVariant(data.FamilyCode.ValueString(), data.Code.ValueString())
+	switch {
+	case err == nil && !data.AdoptExisting.ValueBool():
+		addAlreadyExistsError(&resp.Diagnostics, "family variant", data.Code.ValueString())
+		return
+	case err == nil:
+		err = r.client.UpdateFamilyVariant(data.FamilyCode.ValueString(), data.Code.ValueString(), *apiData)
+	case akeneox.IsNotFound(err):
+		err = r.client.CreateFamilyVariant(data.FamilyCode.ValueString(), *apiData)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while creating a family variant",
@@ -180,11 +319,28 @@ func (r *FamilyVariantResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	if resp.Diagnostics.HasError() {
+	// Akeneo adds the identifier attribute to the last level on its own. The
+	// identifier attribute is created with the catalog, so the snapshot shared
+	// with the other resources is recent enough to find it.
+	attributes, err := r.catalog.Attributes()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading attributes",
+			"An unexpected error occurred when listing identifier attributes. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
 		return
 	}
 
-	r.mapToTfObject(&resp.Diagnostics, &data, apiData)
+	implicit := make(map[string]bool)
+	for _, attribute := range attributes {
+		if attribute.Type == "pim_catalog_identifier" {
+			implicit[attribute.Code] = true
+		}
+	}
+
+	r.mapToTfObject(&resp.Diagnostics, &data, apiData, implicit)
+	data.AdoptExisting = adoptExistingValue(data.AdoptExisting)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -210,7 +366,9 @@ func (r *FamilyVariantResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	err := r.client.UpdateOrCreate(data.FamilyCode.ValueString(), data.Code.ValueString(), *apiData)
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+
+	err := r.client.UpdateFamilyVariant(data.FamilyCode.ValueString(), data.Code.ValueString(), *apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while updating a family",
@@ -248,49 +406,120 @@ func (r *FamilyVariantResource) ImportState(ctx context.Context, req resource.Im
 	resource.ImportStatePassthroughID(ctx, path.Root("code"), req, resp)
 }
 
-func (r *FamilyVariantResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *FamilyVariantResourceModel) *goakeneo.FamilyVariant {
-	a := goakeneo.FamilyVariant{
-		Code: data.Code.ValueString(),
+// validateAxes checks the variant axes against the rules Akeneo applies, so
+// invalid axes are reported during plan. Attributes and families which do not
+// exist yet are skipped, as they may be created within the same apply.
+func (r *FamilyVariantResource) validateAxes(ctx context.Context, diags *diag.Diagnostics, data *FamilyVariantResourceModel) {
+	var familyAttributes map[string]bool
+	if !data.FamilyCode.IsUnknown() {
+		family, err := r.client.GetFamily(data.FamilyCode.ValueString())
+		if err != nil && !akeneox.IsNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("family_code"),
+				"Error while reading a family",
+				"An unexpected error occurred when reading family "+data.FamilyCode.ValueString()+". \n\n"+
+					"Akeneo API Error: "+err.Error(),
+			)
+			return
+		}
+		if family != nil {
+			familyAttributes = make(map[string]bool, len(family.Attributes))
+			for _, code := range family.Attributes {
+				familyAttributes[code] = true
+			}
+		}
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
+	for level, set := range data.VariantAttributeSets {
+		setPath := path.Root("variant_attribute_sets").AtMapKey(level)
+		if set.Axes.IsNull() || set.Axes.IsUnknown() {
+			continue
 		}
-		a.Labels = labels
-	}
 
-	sets := make([]goakeneo.VariantAttributeSet, len(data.VariantAttributeSets))
-	for i, set := range data.VariantAttributeSets {
-		s := goakeneo.VariantAttributeSet{
-			Level: int(set.Level.ValueInt64()),
+		if len(set.Axes.Elements()) > maxVariantAxesPerLevel {
+			diags.AddAttributeError(
+				setPath.AtName("axes"),
+				"Too many variant axes",
+				fmt.Sprintf("Level %s has %d axes, Akeneo allows at most %d axes per level.", level, len(set.Axes.Elements()), maxVariantAxesPerLevel),
+			)
 		}
 
-		if !set.Axes.IsNull() {
-			elements := make([]types.String, len(set.Axes.Elements()))
-			diags.Append(set.Axes.ElementsAs(ctx, &elements, false)...)
-			axes := make([]string, len(elements))
-			for j, axis := range elements {
-				axes[j] = axis.ValueString()
+		for j, v := range set.Axes.Elements() {
+			axis, ok := v.(types.String)
+			if !ok || axis.IsUnknown() {
+				continue
+			}
+			code := axis.ValueString()
+			axisPath := setPath.AtName("axes").AtListIndex(j)
+
+			if familyAttributes != nil && !familyAttributes[code] {
+				diags.AddAttributeError(
+					axisPath,
+					"Invalid variant axis",
+					fmt.Sprintf("Attribute %s is not an attribute of the family %s.", code, data.FamilyCode.ValueString()),
+				)
+			}
+
+			attribute, err := r.attributeClient.GetAttribute(code)
+			if err != nil {
+				if !akeneox.IsNotFound(err) {
+					diags.AddAttributeError(
+						axisPath,
+						"Error while reading an attribute",
+						"An unexpected error occurred when reading attribute "+code+". \n\n"+
+							"Akeneo API Error: "+err.Error(),
+					)
+				}
+				continue
+			}
+
+			if !variantAxisAttributeTypes[attribute.Type] {
+				diags.AddAttributeError(
+					axisPath,
+					"Invalid variant axis",
+					fmt.Sprintf("Attribute %s is of type %s, variant axes must be simple select, reference entity single link, metric, yes/no or reference data simple select attributes.", code, attribute.Type),
+				)
 			}
-			s.Axes = axes
-		}
 
-		if !set.Attributes.IsNull() {
-			elements := make([]types.String, len(set.Attributes.Elements()))
-			diags.Append(set.Attributes.ElementsAs(ctx, &elements, false)...)
-			attributes := make([]string, len(elements))
-			for j, a := range elements {
-				attributes[j] = a.ValueString()
+			if attribute.Localizable != nil && *attribute.Localizable || attribute.Scopable != nil && *attribute.Scopable {
+				diags.AddAttributeError(
+					axisPath,
+					"Invalid variant axis",
+					fmt.Sprintf("Attribute %s is localizable or scopable, variant axes must have a single value for every locale and channel.", code),
+				)
 			}
-			s.Attributes = attributes
+		}
+	}
+}
+
+func (r *FamilyVariantResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *FamilyVariantResourceModel) *goakeneo.FamilyVariant {
+	a := goakeneo.FamilyVariant{
+		Code: data.Code.ValueString(),
+	}
+
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
+
+	sets := make([]goakeneo.VariantAttributeSet, 0, len(data.VariantAttributeSets))
+	for key, set := range data.VariantAttributeSets {
+		level, err := strconv.Atoi(key)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("variant_attribute_sets").AtMapKey(key),
+				"Invalid enrichment level",
+				"Enrichment level "+key+" is not a number.",
+			)
+			continue
 		}
 
-		sets[i] = s
+		sets = append(sets, goakeneo.VariantAttributeSet{
+			Level:      level,
+			Axes:       typesx.ListElements[string](ctx, diags, set.Axes),
+			Attributes: typesx.SetElements[string](ctx, diags, set.Attributes),
+		})
 	}
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].Level < sets[j].Level
+	})
 	a.VariantAttributeSets = sets
 
 	if diags.HasError() {
@@ -300,57 +529,49 @@ func (r *FamilyVariantResource) mapToApiObject(ctx context.Context, diags *diag.
 	return &a
 }
 
-func (r *FamilyVariantResource) mapToTfObject(respDiags *diag.Diagnostics, data *FamilyVariantResourceModel, apiData *goakeneo.FamilyVariant) {
-	// todo map only non empties
+// mapToTfObject maps the family variant into the model. Attributes Akeneo adds
+// to a level implicitly, the axes of the level and the given implicit attributes,
+// are left out unless the prior state of the level manages them. Empty labels
+// are mapped to null.
+func (r *FamilyVariantResource) mapToTfObject(respDiags *diag.Diagnostics, data *FamilyVariantResourceModel, apiData *goakeneo.FamilyVariant, implicit map[string]bool) {
 	data.Code = types.StringValue(apiData.Code)
-
-	if len(apiData.Labels) > 0 {
-		elements := make(map[string]attr.Value, len(apiData.Labels))
-
-		for k, v := range apiData.Labels {
-			elements[k] = types.StringValue(v)
-		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
-		}
-		data.Labels = mapVal
-	}
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, apiData.Labels)
 
 	if len(apiData.VariantAttributeSets) > 0 {
-		sets := make([]VariantAttributeSetModel, len(apiData.VariantAttributeSets))
-		for i, set := range apiData.VariantAttributeSets {
-			s := VariantAttributeSetModel{
-				Level: types.Int64Value(int64(set.Level)),
-			}
-
-			if len(set.Axes) > 0 {
-				axes := make([]attr.Value, len(set.Axes))
-				for j, axis := range set.Axes {
-					axes[j] = types.StringValue(axis)
+		sets := make(map[string]VariantAttributeSetModel, len(apiData.VariantAttributeSets))
+		for _, set := range apiData.VariantAttributeSets {
+			key := strconv.Itoa(set.Level)
+			prior := data.VariantAttributeSets[key]
+
+			managed := make(map[string]bool)
+			for _, v := range prior.Attributes.Elements() {
+				if code, ok := v.(types.String); ok {
+					managed[code.ValueString()] = true
 				}
-				listVal, diags := types.ListValue(types.StringType, axes)
-				if diags.HasError() {
-					respDiags.Append(diags...)
-				}
-				s.Axes = listVal
+			}
+			levelImplicit := make(map[string]bool, len(set.Axes)+len(implicit))
+			for code := range implicit {
+				levelImplicit[code] = true
+			}
+			for _, axis := range set.Axes {
+				levelImplicit[axis] = true
 			}
 
-			if len(set.Attributes) > 0 {
-				attributes := make([]attr.Value, len(set.Attributes))
-				for j, a := range set.Attributes {
-					attributes[j] = types.StringValue(a)
+			attributes := make([]string, 0, len(set.Attributes))
+			for _, a := range set.Attributes {
+				if levelImplicit[a] && !managed[a] {
+					continue
 				}
-				listVal, diags := types.ListValue(types.StringType, attributes)
-				if diags.HasError() {
-					respDiags.Append(diags...)
-				}
-				s.Attributes = listVal
+				attributes = append(attributes, a)
 			}
 
-			sets[i] = s
+			sets[key] = VariantAttributeSetModel{
+				Axes:       typesx.ListValue(respDiags, set.Axes),
+				Attributes: typesx.SetValue(respDiags, attributes),
+			}
 		}
 		data.VariantAttributeSets = sets
+	} else {
+		data.VariantAttributeSets = nil
 	}
 }
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccFamilyVariantFamilyConfig = `
+resource "akeneo_attribute" "color" {
+  code  = "color"
+  type  = "pim_catalog_simpleselect"
+  group = "other"
+}
+
+resource "akeneo_attribute" "size" {
+  code  = "size"
+  type  = "pim_catalog_simpleselect"
+  group = "other"
+}
+
+resource "akeneo_attribute" "name" {
+  code  = "name"
+  type  = "pim_catalog_text"
+  group = "other"
+}
+
+resource "akeneo_family" "shoes" {
+  code = "shoes"
+  attributes = [
+    "sku",
+    akeneo_attribute.color.code,
+    akeneo_attribute.size.code,
+    akeneo_attribute.name.code,
+  ]
+
+  attribute_requirements = {
+    ecommerce = ["sku"]
+  }
+}
+`
+
+func TestAccFamilyVariantResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccFamilyVariantFamilyConfig+`
+resource "akeneo_family_variant" "test" {
+  family_code = akeneo_family.shoes.code
+  code        = "shoes_by_color_size"
+  labels      = { en_US = "Shoes by color and size" }
+
+  variant_attribute_sets = {
+    "1" = { axes = [akeneo_attribute.color.code] }
+    "2" = { axes = [akeneo_attribute.size.code] }
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_family_variant.test", "code", "shoes_by_color_size"),
+					resource.TestCheckResourceAttr("akeneo_family_variant.test", "variant_attribute_sets.1.axes.0", "color"),
+					resource.TestCheckResourceAttr("akeneo_family_variant.test", "variant_attribute_sets.2.axes.0", "size"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, testAccFamilyVariantFamilyConfig+`
+resource "akeneo_family_variant" "test" {
+  family_code = akeneo_family.shoes.code
+  code        = "shoes_by_color_size"
+  labels      = { en_US = "Shoes by color and size" }
+
+  variant_attribute_sets = {
+    "1" = { axes = [akeneo_attribute.color.code], attributes = [akeneo_attribute.color.code, akeneo_attribute.name.code] }
+    "2" = { axes = [akeneo_attribute.size.code] }
+  }
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_family_variant.test", "variant_attribute_sets.1.attributes.#", "2"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server,
+					"akeneo_attribute.color", "akeneo_attribute.size", "akeneo_attribute.name",
+					"akeneo_family.shoes", "akeneo_family_variant.test",
+				),
+			},
+		},
+	})
+}
+
+func TestFamilyVariantResourceUpgradeState_v0(t *testing.T) {
+	var data FamilyVariantResourceModel
+	testUpgradeState(t, NewFamilyVariantResource(), 0, `{
+  "family_code": "shoes",
+  "code": "shoes_by_color_size",
+  "labels": {"en_US": "Shoes by color and size"},
+  "variant_attribute_sets": [
+    {"level": 1, "axes": ["color"], "attributes": ["color", "name", "color"]},
+    {"level": 2, "axes": ["size"], "attributes": ["size"]}
+  ]
+}`, &data)
+
+	labels := testLabelsMap(t, map[string]string{"en_US": "Shoes by color and size"})
+	if !data.Labels.Equal(labels) || !data.EffectiveLabels.Equal(labels) || !data.Label.IsNull() {
+		t.Errorf("expected labels and effective_labels %s without label, got %s, %s and %s", labels, data.Labels, data.EffectiveLabels, data.Label)
+	}
+
+	expected := map[string]VariantAttributeSetModel{
+		"1": {
+			Axes:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("color")}),
+			Attributes: testStringSet("color", "name"),
+		},
+		"2": {
+			Axes:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("size")}),
+			Attributes: testStringSet("size"),
+		},
+	}
+	if len(data.VariantAttributeSets) != len(expected) {
+		t.Fatalf("expected variant_attribute_sets for levels 1 and 2, got %v", data.VariantAttributeSets)
+	}
+	for level, set := range expected {
+		got, ok := data.VariantAttributeSets[level]
+		if !ok || !got.Axes.Equal(set.Axes) || !got.Attributes.Equal(set.Attributes) {
+			t.Errorf("expected variant attribute set %s to be %v, got %v", level, set, got)
+		}
+	}
+}
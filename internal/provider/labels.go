@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// labelAttribute is the schema of the label shorthand, a single label used for
+// all locales of the provider default_label_locales.
+func labelAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "Label used for all locales of the provider default_label_locales. Labels set per locale take precedence",
+		Optional:    true,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// effectiveLabelsAttribute is the schema of the labels sent to Akeneo, the
+// configured labels with the defaults of the provider applied.
+func effectiveLabelsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "Labels per locale sent to Akeneo, the labels with the label shorthand, or a single label, expanded to the provider default_label_locales",
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+}
+
+// labelDefaults expands the labels of a resource to the locales of the
+// provider default_label_locales.
+type labelDefaults struct {
+	locales []string
+	// configured is false until the provider is configured, the locales are
+	// not known before.
+	configured bool
+}
+
+func newLabelDefaults(data *ResourceData) labelDefaults {
+	return labelDefaults{
+		locales:    data.DefaultLabelLocales,
+		configured: true,
+	}
+}
+
+// modifyPlan plans the effective_labels of a resource with the labels and
+// label attributes.
+//
+// The label shorthand, or the only label of the labels map, is used for every
+// default locale. Labels set per locale take precedence over the defaults.
+func (d labelDefaults) modifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var labels types.Map
+	var label types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("label"), &label)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unknown := types.MapUnknown(types.StringType)
+
+	if labels.IsUnknown() || label.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), unknown)...)
+		return
+	}
+
+	explicit := make(map[string]types.String, len(labels.Elements()))
+	if !labels.IsNull() {
+		resp.Diagnostics.Append(labels.ElementsAs(ctx, &explicit, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, v := range explicit {
+		if v.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), unknown)...)
+			return
+		}
+	}
+
+	var base types.String
+	switch {
+	case !label.IsNull():
+		base = label
+	case len(explicit) == 1:
+		for _, v := range explicit {
+			base = v
+		}
+	}
+
+	if !base.IsNull() && !d.configured {
+		// The default locales are only known once the provider is configured.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), unknown)...)
+		return
+	}
+
+	if !label.IsNull() && len(d.locales) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("label"),
+			"Missing default label locales",
+			"The label attribute is used for all locales of default_label_locales, which is not set in the provider configuration. "+
+				"Set default_label_locales or use the labels attribute.",
+		)
+		return
+	}
+
+	elements := make(map[string]string, len(d.locales)+len(explicit))
+	if !base.IsNull() {
+		for _, locale := range d.locales {
+			elements[locale] = base.ValueString()
+		}
+	}
+	for locale, v := range explicit {
+		elements[locale] = v.ValueString()
+	}
+
+	effective := typesx.MapValue(&resp.Diagnostics, elements)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), effective)...)
+}
+
+// labelsToApi returns the labels sent to Akeneo.
+func labelsToApi(ctx context.Context, diags *diag.Diagnostics, value types.Map) map[string]string {
+	return typesx.MapElements[string](ctx, diags, value)
+}
+
+// removedLabelsToApi adds an empty label for the locales of the effective_labels
+// state which are no longer labeled. Akeneo merges the labels of an update and
+// only removes the label of a locale for an empty value.
+func removedLabelsToApi(ctx context.Context, diags *diag.Diagnostics, state tfsdk.State, labels map[string]string) map[string]string {
+	var prior types.Map
+	diags.Append(state.GetAttribute(ctx, path.Root("effective_labels"), &prior)...)
+
+	for locale := range prior.Elements() {
+		if _, ok := labels[locale]; !ok {
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[locale] = ""
+		}
+	}
+
+	return labels
+}
+
+// labelsFromApi returns the labels and effective_labels state for the labels
+// read from Akeneo.
+//
+// The labels attribute only keeps the locales it already holds, the labels of
+// the default locales are tracked by effective_labels. Imported resources get
+// all labels.
+func labelsFromApi(respDiags *diag.Diagnostics, prior types.Map, effectivePrior types.Map, labels map[string]string) (types.Map, types.Map) {
+	effective := typesx.MapValue(respDiags, labels)
+
+	if prior.IsNull() || prior.IsUnknown() {
+		// The effective labels are only missing for imported resources.
+		if effectivePrior.IsNull() {
+			return effective, effective
+		}
+		return types.MapNull(types.StringType), effective
+	}
+
+	kept := make(map[string]string, len(prior.Elements()))
+	for locale := range prior.Elements() {
+		if v, ok := labels[locale]; ok {
+			kept[locale] = v
+		}
+	}
+
+	return typesx.MapValue(respDiags, kept), effective
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &LabelsFunction{}
+
+func NewLabelsFunction() function.Function {
+	return &LabelsFunction{}
+}
+
+// LabelsFunction expands a default label across locales.
+type LabelsFunction struct{}
+
+func (f *LabelsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "labels"
+}
+
+func (f *LabelsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a label map from a default label",
+		Description: "Returns a label map with the default label for each of the given locales, overridden by the labels in overrides. Provider functions can not read the provider configuration, so the locales are passed as a list argument, which a module can keep in a single variable. Example: provider::akeneo::labels(\"Color\", { fr_FR = \"Couleur\" }, [\"en_US\", \"de_DE\"])",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "default",
+				Description: "Label used for every locale without an override",
+			},
+			function.MapParameter{
+				Name:               "overrides",
+				Description:        "Labels per locale which replace the default label",
+				ElementType:        types.StringType,
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+			function.ListParameter{
+				Name:        "locales",
+				Description: "Locales to set the default label for",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *LabelsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var defaultLabel string
+	var overrides map[string]string
+	var locales []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &defaultLabel, &overrides, &locales))
+
+	if resp.Error != nil {
+		return
+	}
+
+	labels := make(map[string]string, len(locales)+len(overrides))
+	for i, locale := range locales {
+		if !stringvalidatorx.IsValidLocaleCode(locale) {
+			resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid locale code %q at index %d, expected a code like en_US", locale, i))
+			return
+		}
+		labels[locale] = defaultLabel
+	}
+
+	for locale, label := range overrides {
+		if !stringvalidatorx.IsValidLocaleCode(locale) {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid locale code %q, expected a code like en_US", locale))
+			return
+		}
+		labels[locale] = label
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, labels))
+}
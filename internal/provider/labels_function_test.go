@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestLabelsFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::akeneo::labels("Color", { fr_FR = "Couleur" }, ["en_US", "de_DE", "fr_FR"])
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"en_US": knownvalue.StringExact("Color"),
+						"de_DE": knownvalue.StringExact("Color"),
+						"fr_FR": knownvalue.StringExact("Couleur"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestLabelsFunction_NullOverrides(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::akeneo::labels("Color", null, ["en_US"])
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"en_US": knownvalue.StringExact("Color"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestLabelsFunction_InvalidLocale(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::akeneo::labels("Color", null, ["en_US", "english"])
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+locale\s+code\s+"english"\s+at\s+index\s+1`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::akeneo::labels("Color", { french = "Couleur" }, ["en_US"])
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+locale\s+code\s+"french"`),
+			},
+		},
+	})
+}
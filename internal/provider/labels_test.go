@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var testLabelsSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"labels": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"label":            labelAttribute(),
+		"effective_labels": effectiveLabelsAttribute(),
+	},
+}
+
+// testLabelsValue returns the raw value of a resource with the labels, label
+// and effective_labels attributes, nil maps and strings are null.
+func testLabelsValue(labels map[string]string, label *string, effective tftypes.Value) tftypes.Value {
+	objectType := testLabelsSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	mapType := tftypes.Map{ElementType: tftypes.String}
+
+	labelsValue := tftypes.NewValue(mapType, nil)
+	if labels != nil {
+		elements := make(map[string]tftypes.Value, len(labels))
+		for k, v := range labels {
+			elements[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		labelsValue = tftypes.NewValue(mapType, elements)
+	}
+
+	var labelValue any
+	if label != nil {
+		labelValue = *label
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"labels":           labelsValue,
+		"label":            tftypes.NewValue(tftypes.String, labelValue),
+		"effective_labels": effective,
+	})
+}
+
+func testLabelsMap(t *testing.T, labels map[string]string) types.Map {
+	t.Helper()
+
+	elements := make(map[string]attr.Value, len(labels))
+	for k, v := range labels {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestLabelDefaultsModifyPlan(t *testing.T) {
+	label := "Shoes"
+	mapType := tftypes.Map{ElementType: tftypes.String}
+
+	cases := []struct {
+		name      string
+		defaults  labelDefaults
+		labels    map[string]string
+		label     *string
+		expected  map[string]string
+		unknown   bool
+		expectErr bool
+	}{
+		{
+			name:     "label shorthand",
+			defaults: labelDefaults{locales: []string{"en_US", "fr_FR"}, configured: true},
+			label:    &label,
+			expected: map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"},
+		},
+		{
+			name:     "single labels entry",
+			defaults: labelDefaults{locales: []string{"en_US", "fr_FR"}, configured: true},
+			labels:   map[string]string{"en_US": "Shoes"},
+			expected: map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"},
+		},
+		{
+			name:     "single labels entry of another locale",
+			defaults: labelDefaults{locales: []string{"en_US"}, configured: true},
+			labels:   map[string]string{"de_DE": "Schuhe"},
+			expected: map[string]string{"en_US": "Schuhe", "de_DE": "Schuhe"},
+		},
+		{
+			name:     "explicit per-locale override",
+			defaults: labelDefaults{locales: []string{"en_US", "fr_FR"}, configured: true},
+			labels:   map[string]string{"fr_FR": "Chaussures"},
+			label:    &label,
+			expected: map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"},
+		},
+		{
+			name:     "several labels are not expanded",
+			defaults: labelDefaults{locales: []string{"en_US", "fr_FR", "de_DE"}, configured: true},
+			labels:   map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"},
+			expected: map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"},
+		},
+		{
+			name:     "no labels",
+			defaults: labelDefaults{locales: []string{"en_US"}, configured: true},
+		},
+		{
+			name:     "single labels entry without default locales",
+			defaults: labelDefaults{configured: true},
+			labels:   map[string]string{"en_US": "Shoes"},
+			expected: map[string]string{"en_US": "Shoes"},
+		},
+		{
+			name:      "label shorthand without default locales",
+			defaults:  labelDefaults{configured: true},
+			label:     &label,
+			expectErr: true,
+		},
+		{
+			name:    "label shorthand with an unconfigured provider",
+			label:   &label,
+			unknown: true,
+		},
+		{
+			name:    "single labels entry with an unconfigured provider",
+			labels:  map[string]string{"en_US": "Shoes"},
+			unknown: true,
+		},
+		{
+			name:     "several labels with an unconfigured provider",
+			labels:   map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"},
+			expected: map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{
+					Schema: testLabelsSchema,
+					Raw:    testLabelsValue(c.labels, c.label, tftypes.NewValue(mapType, nil)),
+				},
+				Plan: tfsdk.Plan{
+					Schema: testLabelsSchema,
+					Raw:    testLabelsValue(c.labels, c.label, tftypes.NewValue(mapType, tftypes.UnknownValue)),
+				},
+				State: tfsdk.State{
+					Schema: testLabelsSchema,
+					Raw:    tftypes.NewValue(testLabelsSchema.Type().TerraformType(ctx), nil),
+				},
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+
+			c.defaults.modifyPlan(ctx, req, &resp)
+			if c.expectErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var effective types.Map
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("effective_labels"), &effective)...)
+
+			expected := types.MapNull(types.StringType)
+			switch {
+			case c.unknown:
+				expected = types.MapUnknown(types.StringType)
+			case c.expected != nil:
+				expected = testLabelsMap(t, c.expected)
+			}
+			if !effective.Equal(expected) {
+				t.Errorf("expected effective labels %s, got %s", expected, effective)
+			}
+		})
+	}
+}
+
+func TestLabelsFromApi(t *testing.T) {
+	null := types.MapNull(types.StringType)
+
+	cases := []struct {
+		name              string
+		prior             types.Map
+		effectivePrior    types.Map
+		labels            map[string]string
+		expected          types.Map
+		expectedEffective types.Map
+	}{
+		{
+			name:              "import",
+			prior:             null,
+			effectivePrior:    null,
+			labels:            map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"},
+			expected:          testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"}),
+			expectedEffective: testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"}),
+		},
+		{
+			name:              "import without labels",
+			prior:             null,
+			effectivePrior:    null,
+			expected:          null,
+			expectedEffective: null,
+		},
+		{
+			name:              "label shorthand",
+			prior:             null,
+			effectivePrior:    testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"}),
+			labels:            map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"},
+			expected:          null,
+			expectedEffective: testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"}),
+		},
+		{
+			name:              "default locales are only kept in the effective labels",
+			prior:             testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+			effectivePrior:    testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"}),
+			labels:            map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"},
+			expected:          testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+			expectedEffective: testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Shoes"}),
+		},
+		{
+			name:              "changed label",
+			prior:             testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+			effectivePrior:    testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+			labels:            map[string]string{"en_US": "Sneakers"},
+			expected:          testLabelsMap(t, map[string]string{"en_US": "Sneakers"}),
+			expectedEffective: testLabelsMap(t, map[string]string{"en_US": "Sneakers"}),
+		},
+		{
+			name:              "locale removed outside of terraform",
+			prior:             testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"}),
+			effectivePrior:    testLabelsMap(t, map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"}),
+			labels:            map[string]string{"en_US": "Shoes"},
+			expected:          testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+			expectedEffective: testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+		},
+		{
+			name:              "all labels removed outside of terraform",
+			prior:             testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+			effectivePrior:    testLabelsMap(t, map[string]string{"en_US": "Shoes"}),
+			expected:          null,
+			expectedEffective: null,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			labels, effective := labelsFromApi(&diags, c.prior, c.effectivePrior, c.labels)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !labels.Equal(c.expected) {
+				t.Errorf("expected labels %s, got %s", c.expected, labels)
+			}
+			if !effective.Equal(c.expectedEffective) {
+				t.Errorf("expected effective labels %s, got %s", c.expectedEffective, effective)
+			}
+		})
+	}
+}
+
+func TestRemovedLabelsToApi(t *testing.T) {
+	cases := []struct {
+		name           string
+		effectivePrior map[string]string
+		labels         map[string]string
+		expected       map[string]string
+	}{
+		{
+			name:           "removed locale",
+			effectivePrior: map[string]string{"en_US": "Shoes", "fr_FR": "Chaussures"},
+			labels:         map[string]string{"en_US": "Shoes"},
+			expected:       map[string]string{"en_US": "Shoes", "fr_FR": ""},
+		},
+		{
+			name:           "all labels removed",
+			effectivePrior: map[string]string{"en_US": "Shoes"},
+			expected:       map[string]string{"en_US": ""},
+		},
+		{
+			name:     "no prior labels",
+			labels:   map[string]string{"en_US": "Shoes"},
+			expected: map[string]string{"en_US": "Shoes"},
+		},
+	}
+
+	mapType := tftypes.Map{ElementType: tftypes.String}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			effective := tftypes.NewValue(mapType, nil)
+			if c.effectivePrior != nil {
+				elements := make(map[string]tftypes.Value, len(c.effectivePrior))
+				for k, v := range c.effectivePrior {
+					elements[k] = tftypes.NewValue(tftypes.String, v)
+				}
+				effective = tftypes.NewValue(mapType, elements)
+			}
+			state := tfsdk.State{
+				Schema: testLabelsSchema,
+				Raw:    testLabelsValue(nil, nil, effective),
+			}
+
+			labels := removedLabelsToApi(context.Background(), &diags, state, c.labels)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !testLabelsMap(t, labels).Equal(testLabelsMap(t, c.expected)) {
+				t.Errorf("expected labels %v, got %v", c.expected, labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &LocaleValidFunction{}
+
+func NewLocaleValidFunction() function.Function {
+	return &LocaleValidFunction{}
+}
+
+// LocaleValidFunction checks a locale code with the same rules as the schema validators.
+type LocaleValidFunction struct{}
+
+func (f *LocaleValidFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "locale_valid"
+}
+
+func (f *LocaleValidFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check whether a string is a valid locale code",
+		Description: "Returns true when the value is a locale code accepted by the provider, e.g. en_US.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "Locale code to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *LocaleValidFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, stringvalidatorx.IsValidLocaleCode(value)))
+}
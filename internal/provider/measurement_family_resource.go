@@ -4,24 +4,28 @@ import (
 	"context"
 	"fmt"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
 	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"regexp"
+	"slices"
+	"sort"
+	"strings"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MeasurementFamilyResource{}
 var _ resource.ResourceWithImportState = &MeasurementFamilyResource{}
 var _ resource.ResourceWithConfigure = &MeasurementFamilyResource{}
+var _ resource.ResourceWithValidateConfig = &MeasurementFamilyResource{}
+var _ resource.ResourceWithModifyPlan = &MeasurementFamilyResource{}
 
 func NewMeasurementFamilyResource() resource.Resource {
 	return &MeasurementFamilyResource{}
@@ -29,7 +33,9 @@ func NewMeasurementFamilyResource() resource.Resource {
 
 // MeasurementFamilyResource defines the resource implementation.
 type MeasurementFamilyResource struct {
-	client *akeneox.MeasurementFamilyService
+	client          *akeneox.MeasurementFamilyStore
+	attributeClient *akeneox.AttributeService
+	labels          labelDefaults
 }
 
 //TODO: use map for units just as the api does because akeneo returns objects in unpredictable order and tf always thinks there is a change
@@ -51,7 +57,10 @@ type MeasurementFamilyResourceModel struct {
 	Code             types.String                         `tfsdk:"code"`
 	StandardUnitCode types.String                         `tfsdk:"standard_unit_code"`
 	Labels           types.Map                            `tfsdk:"labels"`
+	Label            types.String                         `tfsdk:"label"`
+	EffectiveLabels  types.Map                            `tfsdk:"effective_labels"`
 	Units            []MeasurementFamilyResourceUnitModel `tfsdk:"units"`
+	AdoptExisting    types.Bool                           `tfsdk:"adopt_existing"`
 }
 
 func (r *MeasurementFamilyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,7 +77,7 @@ func (r *MeasurementFamilyResource) Schema(ctx context.Context, req resource.Sch
 				Required:    true,
 			},
 			"standard_unit_code": schema.StringAttribute{
-				Description: "Unit code used as the standard unit for this measurement family",
+				Description: "Unit code used as the standard unit for this measurement family. It can not be changed once attributes use the measurement family.",
 				Required:    true,
 			},
 			"labels": schema.MapAttribute{
@@ -79,8 +88,11 @@ func (r *MeasurementFamilyResource) Schema(ctx context.Context, req resource.Sch
 					mapvalidator.KeysAre(stringvalidatorx.IsLocaleCode()),
 				},
 			},
+			"label":            labelAttribute(),
+			"effective_labels": effectiveLabelsAttribute(),
+			"adopt_existing":   adoptExistingAttribute(),
 			"units": schema.ListNestedAttribute{
-				Description: "Unit definitions",
+				Description: "Unit definitions. Units can not be removed once attributes use the measurement family.",
 				Required:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -101,7 +113,7 @@ func (r *MeasurementFamilyResource) Schema(ctx context.Context, req resource.Sch
 							Required:    true,
 						},
 						"convert_from_standard": schema.ListNestedAttribute{
-							Description: "Calculation to convert the unit from the standard unit.",
+							Description: "Calculation to convert the unit from the standard unit, applied from first to last operation. The standard unit must use the single operation mul 1. The conversion_operations provider function builds the operations from a formula.",
 							Optional:    true,
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
@@ -116,10 +128,7 @@ func (r *MeasurementFamilyResource) Schema(ctx context.Context, req resource.Sch
 										Description: "The value for a conversion operation to convert the unit from the standard unit.",
 										Required:    true,
 										Validators: []validator.String{
-											stringvalidator.RegexMatches(
-												regexp.MustCompile(`^-?\d*(\.\d+)?$`),
-												"must only contain decimal values",
-											),
+											stringvalidatorx.IsDecimal(),
 										},
 									},
 								},
@@ -135,6 +144,73 @@ func (r *MeasurementFamilyResource) Schema(ctx context.Context, req resource.Sch
 	}
 }
 
+func (r *MeasurementFamilyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data MeasurementFamilyResourceModel
+	var units types.List
+
+	// Units computed from unknown values can only be checked during apply.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("units"), &units)...)
+
+	if resp.Diagnostics.HasError() || units.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.StandardUnitCode.IsUnknown() {
+		return
+	}
+
+	standardUnitFound := false
+	for i, unit := range data.Units {
+		if unit.Code.IsUnknown() {
+			standardUnitFound = true
+		}
+
+		for j, conversion := range unit.ConvertFromStandard {
+			if conversion.Operator.ValueString() == "div" && isZeroDecimal(conversion.Value.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("units").AtListIndex(i).AtName("convert_from_standard").AtListIndex(j).AtName("value"),
+					"Invalid conversion operation",
+					"Conversion of unit "+unit.Code.ValueString()+" divides by zero.",
+				)
+			}
+		}
+
+		if unit.Code.IsUnknown() || unit.Code.ValueString() != data.StandardUnitCode.ValueString() {
+			continue
+		}
+		standardUnitFound = true
+
+		conversions := make([]akeneox.MeasurementUnitConversion, len(unit.ConvertFromStandard))
+		for j, conversion := range unit.ConvertFromStandard {
+			if conversion.Operator.IsUnknown() || conversion.Value.IsUnknown() {
+				return
+			}
+			conversions[j] = akeneox.MeasurementUnitConversion{
+				Operator: conversion.Operator.ValueString(),
+				Value:    conversion.Value.ValueString(),
+			}
+		}
+
+		if unit.ConvertFromStandard != nil && !isIdentityConversion(conversions) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("units").AtListIndex(i).AtName("convert_from_standard"),
+				"Invalid standard unit conversion",
+				"The standard unit "+unit.Code.ValueString()+" must convert with the single operation mul 1.",
+			)
+		}
+	}
+
+	if !standardUnitFound && data.Units != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("standard_unit_code"),
+			"Unknown standard unit",
+			"The standard unit "+data.StandardUnitCode.ValueString()+" is not one of the units of the measurement family.",
+		)
+	}
+}
+
 func (r *MeasurementFamilyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -159,7 +235,92 @@ func (r *MeasurementFamilyResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	r.client = akeneox.NewMeasurementFamilyClient(data.Client)
+	r.labels = newLabelDefaults(data)
+	r.client = data.MeasurementFamilies
+	r.attributeClient = akeneox.NewAttributeClient(data.Client)
+}
+
+func (r *MeasurementFamilyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.labels.modifyPlan(ctx, req, resp)
+
+	// Any unit can be set on create and there is nothing to check on destroy or
+	// before the provider is configured.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.attributeClient == nil {
+		return
+	}
+
+	var data, state MeasurementFamilyResourceModel
+	var units types.List
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("units"), &units)...)
+
+	if resp.Diagnostics.HasError() || units.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || data.StandardUnitCode.IsUnknown() {
+		return
+	}
+
+	planned := make(map[string]bool, len(data.Units))
+	for _, unit := range data.Units {
+		if unit.Code.IsUnknown() {
+			return
+		}
+		planned[unit.Code.ValueString()] = true
+	}
+
+	var removed []string
+	for _, unit := range state.Units {
+		if !planned[unit.Code.ValueString()] {
+			removed = append(removed, unit.Code.ValueString())
+		}
+	}
+	standardChanged := !data.StandardUnitCode.Equal(state.StandardUnitCode)
+
+	if len(removed) == 0 && !standardChanged {
+		return
+	}
+
+	// Akeneo rejects both changes once any attribute uses the measurement family.
+	attributes, err := r.attributeClient.ListMetricAttributes(state.Code.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading attributes",
+			"An unexpected error occurred when searching attributes using measurement family "+state.Code.ValueString()+". \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if len(attributes) == 0 {
+		return
+	}
+
+	codes := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		codes[i] = attribute.Code
+	}
+	usage := fmt.Sprintf("The measurement family %s is used by the attributes %s.", state.Code.ValueString(), strings.Join(codes, ", "))
+
+	if standardChanged {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("standard_unit_code"),
+			"Standard unit can not be changed",
+			usage+" Akeneo does not allow changing the standard unit of a measurement family used by attributes.",
+		)
+	}
+
+	if len(removed) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("units"),
+			"Measurement units can not be removed",
+			usage+" Akeneo does not allow removing the units "+strings.Join(removed, ", ")+" from a measurement family used by attributes.",
+		)
+	}
 }
 
 func (r *MeasurementFamilyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -178,7 +339,16 @@ func (r *MeasurementFamilyResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	result, err := r.client.UpdateMeasurementFamilies([]akeneox.MeasurementFamily{*apiData})
+	var result *akeneox.MeasurementFamilyPatchResponse
+	_, err := r.client.GetMeasurementFamily(apiData.Code)
+	switch {
+	case err == nil && !data.AdoptExisting.ValueBool():
+		addAlreadyExistsError(&resp.Diagnostics, "measurement family", apiData.Code)
+		return
+	case err == nil || akeneox.IsNotFound(err):
+		// The API creates and updates measurement families with the same PATCH request.
+		result, err = r.client.UpdateMeasurementFamily(*apiData)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while creating a measurement family",
@@ -188,28 +358,7 @@ func (r *MeasurementFamilyResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	if result != nil {
-		for _, r := range *result {
-			if r.StatusCode > 299 {
-				if r.Message != "" {
-					resp.Diagnostics.AddError(
-						"Error while creating a measurement family",
-						"An unexpected error occurred when creating measurement family. \n\n"+
-							"Akeneo API Error: "+r.Message,
-					)
-				}
-
-				for _, e := range r.Errors {
-					resp.Diagnostics.AddError(
-						"Error while creating a measurement family",
-						"A validation error was returned from the Akeneo API. \n\n"+
-							"Validation Error: "+e.Message+"\n"+
-							"On property: "+e.Property+"\n",
-					)
-				}
-			}
-		}
-	}
+	addMeasurementFamilyPatchErrors(&resp.Diagnostics, "Error while creating a measurement family", result)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -231,6 +380,10 @@ func (r *MeasurementFamilyResource) Read(ctx context.Context, req resource.ReadR
 
 	attrData, err := r.client.GetMeasurementFamily(data.Code.ValueString())
 	if err != nil {
+		if akeneox.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error while reading a measurement family",
 			"An unexpected error occurred when reading measurement family. \n\n"+
@@ -244,6 +397,7 @@ func (r *MeasurementFamilyResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	r.mapToTfObject(&resp.Diagnostics, &data, attrData)
+	data.AdoptExisting = adoptExistingValue(data.AdoptExisting)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -269,7 +423,14 @@ func (r *MeasurementFamilyResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	result, err := r.client.UpdateMeasurementFamilies([]akeneox.MeasurementFamily{*apiData})
+	apiData.Labels = removedLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Labels)
+	removedUnitLabelsToApi(ctx, &resp.Diagnostics, req.State, apiData.Units)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.UpdateMeasurementFamily(*apiData)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while updating a measurement family",
@@ -279,28 +440,7 @@ func (r *MeasurementFamilyResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	if result != nil {
-		for _, r := range *result {
-			if r.StatusCode > 299 {
-				if r.Message != "" {
-					resp.Diagnostics.AddError(
-						"Error while updating a measurement family",
-						"An unexpected error occurred when creating measurement family. \n\n"+
-							"Akeneo API Error: "+r.Message,
-					)
-				}
-
-				for _, e := range r.Errors {
-					resp.Diagnostics.AddError(
-						"Error while updating a measurement family",
-						"A validation error was returned from the Akeneo API. \n\n"+
-							"Validation Error: "+e.Message+"\n"+
-							"On property: "+e.Property+"\n",
-					)
-				}
-			}
-		}
-	}
+	addMeasurementFamilyPatchErrors(&resp.Diagnostics, "Error while updating a measurement family", result)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -330,37 +470,77 @@ func (r *MeasurementFamilyResource) ImportState(ctx context.Context, req resourc
 	resource.ImportStatePassthroughID(ctx, path.Root("code"), req, resp)
 }
 
+// addMeasurementFamilyPatchErrors reports the status of a single measurement
+// family returned from the batched PATCH request.
+func addMeasurementFamilyPatchErrors(diags *diag.Diagnostics, summary string, result *akeneox.MeasurementFamilyPatchResponse) {
+	if result == nil || result.StatusCode < 300 {
+		return
+	}
+
+	if result.Message != "" {
+		diags.AddError(
+			summary,
+			fmt.Sprintf("Akeneo rejected measurement family %s with status %d. \n\n", result.Code, result.StatusCode)+
+				"Akeneo API Error: "+result.Message,
+		)
+	}
+
+	for _, e := range result.Errors {
+		diags.AddError(
+			summary,
+			"A validation error was returned from the Akeneo API. \n\n"+
+				"Validation Error: "+e.Message+"\n"+
+				"On property: "+e.Property+"\n",
+		)
+	}
+
+	if result.Message == "" && len(result.Errors) == 0 {
+		diags.AddError(
+			summary,
+			fmt.Sprintf("Akeneo rejected measurement family %s with status %d.", result.Code, result.StatusCode),
+		)
+	}
+}
+
+// removedUnitLabelsToApi adds an empty label for the locales of the prior unit
+// labels which are missing from the units, Akeneo merges the labels of a unit
+// and only removes a label sent empty.
+func removedUnitLabelsToApi(ctx context.Context, diags *diag.Diagnostics, state tfsdk.State, units map[string]akeneox.MeasurementUnit) {
+	var prior []MeasurementFamilyResourceUnitModel
+	diags.Append(state.GetAttribute(ctx, path.Root("units"), &prior)...)
+
+	for _, priorUnit := range prior {
+		unit, ok := units[priorUnit.Code.ValueString()]
+		if !ok {
+			continue
+		}
+
+		for locale := range priorUnit.Labels.Elements() {
+			if _, ok := unit.Labels[locale]; !ok {
+				if unit.Labels == nil {
+					unit.Labels = make(map[string]string)
+				}
+				unit.Labels[locale] = ""
+			}
+		}
+		units[unit.Code] = unit
+	}
+}
+
 func (r *MeasurementFamilyResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *MeasurementFamilyResourceModel) *akeneox.MeasurementFamily {
 	a := akeneox.MeasurementFamily{
 		Code:             data.Code.ValueString(),
 		StandardUnitCode: data.StandardUnitCode.ValueString(),
 	}
 
-	if !(data.Labels.IsNull() || data.Labels.IsUnknown()) {
-		elements := make(map[string]types.String, len(data.Labels.Elements()))
-		diags.Append(data.Labels.ElementsAs(ctx, &elements, false)...)
-		labels := make(map[string]string)
-		for locale, label := range elements {
-			labels[locale] = label.ValueString()
-		}
-		a.Labels = labels
-	}
+	a.Labels = labelsToApi(ctx, diags, data.EffectiveLabels)
 
 	units := make(map[string]akeneox.MeasurementUnit, len(data.Units))
 	for _, unit := range data.Units {
 		u := akeneox.MeasurementUnit{
 			Code:   unit.Code.ValueString(),
 			Symbol: unit.Symbol.ValueString(),
-		}
-
-		if !(unit.Labels.IsNull() || unit.Labels.IsUnknown()) {
-			elements := make(map[string]types.String, len(unit.Labels.Elements()))
-			diags.Append(unit.Labels.ElementsAs(ctx, &elements, false)...)
-			labels := make(map[string]string)
-			for locale, label := range elements {
-				labels[locale] = label.ValueString()
-			}
-			u.Labels = labels
+			Labels: typesx.MapElements[string](ctx, diags, unit.Labels),
 		}
 
 		conversions := make([]akeneox.MeasurementUnitConversion, len(unit.ConvertFromStandard))
@@ -387,39 +567,37 @@ func (r *MeasurementFamilyResource) mapToTfObject(respDiags *diag.Diagnostics, d
 	data.Code = types.StringValue(apiData.Code)
 	data.StandardUnitCode = types.StringValue(apiData.StandardUnitCode)
 
-	if apiData.Labels != nil {
-		elements := make(map[string]attr.Value, len(apiData.Labels))
+	data.Labels, data.EffectiveLabels = labelsFromApi(respDiags, data.Labels, data.EffectiveLabels, apiData.Labels)
 
-		for k, v := range apiData.Labels {
-			elements[k] = types.StringValue(v)
+	if len(apiData.Units) > 0 {
+		// The API returns the units as an object, they keep the order of the
+		// prior units and new units are sorted by code. Imported units start
+		// with the standard unit.
+		codes := make([]string, 0, len(apiData.Units))
+		if _, ok := apiData.Units[apiData.StandardUnitCode]; ok && len(data.Units) == 0 {
+			codes = append(codes, apiData.StandardUnitCode)
 		}
-
-		mapVal, diags := types.MapValue(types.StringType, elements)
-		if diags.HasError() {
-			respDiags.Append(diags...)
+		for _, unit := range data.Units {
+			if _, ok := apiData.Units[unit.Code.ValueString()]; ok {
+				codes = append(codes, unit.Code.ValueString())
+			}
 		}
-		data.Labels = mapVal
-	}
+		added := make([]string, 0)
+		for code := range apiData.Units {
+			if !slices.Contains(codes, code) {
+				added = append(added, code)
+			}
+		}
+		sort.Strings(added)
+		codes = append(codes, added...)
 
-	if len(apiData.Units) > 0 {
-		units := make([]MeasurementFamilyResourceUnitModel, len(apiData.Units))
-		i := 0
-		for _, unit := range apiData.Units {
+		units := make([]MeasurementFamilyResourceUnitModel, len(codes))
+		for i, code := range codes {
+			unit := apiData.Units[code]
 			u := MeasurementFamilyResourceUnitModel{
 				Code:   types.StringValue(unit.Code),
 				Symbol: types.StringValue(unit.Symbol),
-			}
-
-			if len(unit.Labels) > 0 {
-				elements := make(map[string]attr.Value, len(unit.Labels))
-				for k, v := range unit.Labels {
-					elements[k] = types.StringValue(v)
-				}
-				mapVal, diags := types.MapValue(types.StringType, elements)
-				if diags.HasError() {
-					respDiags.Append(diags...)
-				}
-				u.Labels = mapVal
+				Labels: typesx.MapValue(respDiags, unit.Labels),
 			}
 
 			if len(unit.ConvertFromStandard) > 0 {
@@ -435,7 +613,6 @@ func (r *MeasurementFamilyResource) mapToTfObject(respDiags *diag.Diagnostics, d
 			}
 
 			units[i] = u
-			i++
 		}
 		data.Units = units
 	}
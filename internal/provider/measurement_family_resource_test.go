@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMeasurementFamilyResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_measurement_family" "test" {
+  code               = "Brightness"
+  standard_unit_code = "LUMEN"
+  labels             = { en_US = "Brightness" }
+
+  units = [
+    {
+      code                  = "LUMEN"
+      symbol                = "lm"
+      labels                = { en_US = "Lumen" }
+      convert_from_standard = [{ operator = "mul", value = "1" }]
+    },
+    {
+      code                  = "KILOLUMEN"
+      symbol                = "klm"
+      labels                = { en_US = "Kilolumen" }
+      convert_from_standard = [{ operator = "mul", value = "1000" }]
+    },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "code", "Brightness"),
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "units.#", "2"),
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "units.0.code", "LUMEN"),
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "units.1.code", "KILOLUMEN"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_measurement_family.test",
+				ImportState:                          true,
+				ImportStateId:                        "Brightness",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_measurement_family" "test" {
+  code               = "Brightness"
+  standard_unit_code = "LUMEN"
+  labels             = { en_US = "Brightness" }
+
+  units = [
+    {
+      code                  = "LUMEN"
+      symbol                = "lm"
+      labels                = { en_US = "Lumen" }
+      convert_from_standard = [{ operator = "mul", value = "1" }]
+    },
+    {
+      code                  = "KILOLUMEN"
+      symbol                = "klm"
+      labels                = { en_US = "Kilolumen" }
+      convert_from_standard = [{ operator = "mul", value = "1000" }]
+    },
+    {
+      code                  = "MILLILUMEN"
+      symbol                = "mlm"
+      convert_from_standard = [{ operator = "div", value = "1000" }]
+    },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "units.#", "3"),
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "units.2.convert_from_standard.0.operator", "div"),
+				),
+			},
+			// Removed labels are removed in Akeneo
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_measurement_family" "test" {
+  code               = "Brightness"
+  standard_unit_code = "LUMEN"
+  labels             = { fr_FR = "Luminosité" }
+
+  units = [
+    {
+      code                  = "LUMEN"
+      symbol                = "lm"
+      labels                = { fr_FR = "Lumen" }
+      convert_from_standard = [{ operator = "mul", value = "1" }]
+    },
+    {
+      code                  = "KILOLUMEN"
+      symbol                = "klm"
+      convert_from_standard = [{ operator = "mul", value = "1000" }]
+    },
+    {
+      code                  = "MILLILUMEN"
+      symbol                = "mlm"
+      convert_from_standard = [{ operator = "div", value = "1000" }]
+    },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "effective_labels.%", "1"),
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "effective_labels.fr_FR", "Luminosité"),
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "units.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("akeneo_measurement_family.test", "units.0.labels.fr_FR", "Lumen"),
+					resource.TestCheckNoResourceAttr("akeneo_measurement_family.test", "units.1.labels.%"),
+				),
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_measurement_family.test"),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const maxCodeLength = 100
+
+var invalidCodeCharsRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+var _ function.Function = &NormalizeCodeFunction{}
+
+func NewNormalizeCodeFunction() function.Function {
+	return &NormalizeCodeFunction{}
+}
+
+// NormalizeCodeFunction turns an arbitrary string into a valid Akeneo code.
+type NormalizeCodeFunction struct{}
+
+func (f *NormalizeCodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_code"
+}
+
+func (f *NormalizeCodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a string into a valid Akeneo code",
+		Description: "Returns the value lowercased with accents removed, every other character than letters, numbers and underscores replaced by an underscore and the result shortened to 100 characters. Example: provider::akeneo::normalize_code(\"Couleur d'été\") returns \"couleur_d_ete\"",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "Value to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeCodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	code := normalizeCode(value)
+	if code == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Value does not contain any character usable in a code")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, code))
+}
+
+func normalizeCode(value string) string {
+	// Decompose accented characters and drop the accents.
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if stripped, _, err := transform.String(t, value); err == nil {
+		value = stripped
+	}
+
+	code := invalidCodeCharsRegexp.ReplaceAllString(strings.ToLower(value), "_")
+	code = strings.Trim(code, "_")
+
+	if len(code) > maxCodeLength {
+		code = strings.TrimRight(code[:maxCodeLength], "_")
+	}
+
+	return code
+}
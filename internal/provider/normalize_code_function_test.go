@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNormalizeCodeFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "accents" {
+  value = provider::akeneo::normalize_code("Couleur d'été")
+}
+
+output "long" {
+  value = provider::akeneo::normalize_code("` + strings.Repeat("a", 99) + ` b")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("accents", knownvalue.StringExact("couleur_d_ete")),
+					statecheck.ExpectKnownOutputValue("long", knownvalue.StringExact(strings.Repeat("a", 99))),
+				},
+			},
+		},
+	})
+}
+
+func TestNormalizeCodeFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::akeneo::normalize_code("!?")
+}
+`,
+				ExpectError: regexp.MustCompile(`Value\s+does\s+not\s+contain\s+any\s+character\s+usable\s+in\s+a\s+code`),
+			},
+		},
+	})
+}
@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProductMediaFileResource{}
+var _ resource.ResourceWithConfigure = &ProductMediaFileResource{}
+var _ resource.ResourceWithModifyPlan = &ProductMediaFileResource{}
+
+func NewProductMediaFileResource() resource.Resource {
+	return &ProductMediaFileResource{}
+}
+
+// ProductMediaFileResource defines the resource implementation.
+type ProductMediaFileResource struct {
+	client *akeneox.ProductMediaFileService
+}
+
+// ProductMediaFileResourceModel describes the resource data model.
+type ProductMediaFileResourceModel struct {
+	File             types.String `tfsdk:"file"`
+	FileSha256       types.String `tfsdk:"file_sha256"`
+	Code             types.String `tfsdk:"code"`
+	OriginalFilename types.String `tfsdk:"original_filename"`
+	MimeType         types.String `tfsdk:"mime_type"`
+	Size             types.Int64  `tfsdk:"size"`
+	Extension        types.String `tfsdk:"extension"`
+}
+
+func (r *ProductMediaFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product_media_file"
+}
+
+func (r *ProductMediaFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Akeneo product media file resource. Uploads a local file, the resulting `code` can be used as the data of product image and file values. The Akeneo API does not support deletion of media files, so destroying the resource only removes it from the state.",
+
+		Attributes: map[string]schema.Attribute{
+			"file": schema.StringAttribute{
+				Description: "Path to the local file to upload",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_sha256": schema.StringAttribute{
+				Description: "SHA256 hash of the file content, the file is uploaded again when it changes",
+				Computed:    true,
+			},
+			"code": schema.StringAttribute{
+				Description: "Media file code",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"original_filename": schema.StringAttribute{
+				Description: "Original name of the uploaded file",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mime_type": schema.StringAttribute{
+				Description: "Mime type of the uploaded file",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "Size of the uploaded file in bytes",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"extension": schema.StringAttribute{
+				Description: "Extension of the uploaded file",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ProductMediaFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	if data.Client == nil {
+		resp.Diagnostics.AddError(
+			"Missing client instance",
+			"Client instance pointer passed to Configure is required, got nil",
+		)
+		return
+	}
+
+	r.client = akeneox.NewProductMediaFileClient(data.Client)
+}
+
+func (r *ProductMediaFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to hash on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data, state ProductMediaFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.File.IsUnknown() {
+		return
+	}
+
+	data.FileSha256 = fileSha256Value(&resp.Diagnostics, data.File.ValueString())
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Media files can not be updated, a changed content is uploaded as a new media file.
+		if !state.FileSha256.Equal(data.FileSha256) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("file_sha256"))
+			data.Code = types.StringUnknown()
+			data.OriginalFilename = types.StringUnknown()
+			data.MimeType = types.StringUnknown()
+			data.Size = types.Int64Unknown()
+			data.Extension = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (r *ProductMediaFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProductMediaFileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The file is only hashed during the plan when its path is known.
+	if data.FileSha256.IsUnknown() {
+		data.FileSha256 = fileSha256Value(&resp.Diagnostics, data.File.ValueString())
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	code, err := r.client.UploadProductMediaFile(data.File.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating a product media file",
+			"An unexpected error occurred when uploading product media file. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	apiData, err := r.client.GetProductMediaFile(code)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while reading a product media file",
+			"An unexpected error occurred when reading uploaded product media file. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	r.mapToTfObject(&data, apiData)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProductMediaFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProductMediaFileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData, err := r.client.GetProductMediaFile(data.Code.ValueString())
+	if err != nil {
+		if akeneox.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error while reading a product media file",
+			"An unexpected error occurred when reading product media file. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	r.mapToTfObject(&data, apiData)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProductMediaFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ProductMediaFileResourceModel
+
+	// Every change of the file replaces the resource, so there is nothing to send.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProductMediaFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"Product media file was not deleted",
+		"The Akeneo API does not support deletes for media files. The media file is only removed from the Terraform state.",
+	)
+}
+
+func (r *ProductMediaFileResource) mapToTfObject(data *ProductMediaFileResourceModel, apiData *goakeneo.MediaFile) {
+	data.Code = types.StringValue(apiData.Code)
+	data.OriginalFilename = types.StringValue(apiData.OriginalFilename)
+	data.MimeType = types.StringValue(apiData.MimeType)
+	data.Size = types.Int64Value(int64(apiData.Size))
+	data.Extension = types.StringValue(apiData.Extension)
+}
+
+// fileSha256Value returns the file_sha256 value of the file, reporting an
+// unreadable file on the file attribute.
+func fileSha256Value(diags *diag.Diagnostics, filePath string) types.String {
+	hash, err := fileSha256(filePath)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("file"),
+			"Unable to read media file",
+			"An unexpected error occurred when reading file "+filePath+". \n\n"+
+				"Error: "+err.Error(),
+		)
+		return types.StringUnknown()
+	}
+
+	return types.StringValue(hash)
+}
+
+// fileSha256 returns the hex encoded SHA256 hash of the file content.
+func fileSha256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProductMediaFileResource(t *testing.T) {
+	server := testAccServer(t)
+
+	image := filepath.Join(t.TempDir(), "sneaker.jpg")
+	if err := os.WriteFile(image, []byte("image content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server, `
+resource "akeneo_product_media_file" "test" {
+  file = "`+filepath.ToSlash(image)+`"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akeneo_product_media_file.test", "code"),
+					resource.TestCheckResourceAttrSet("akeneo_product_media_file.test", "file_sha256"),
+					resource.TestCheckResourceAttr("akeneo_product_media_file.test", "original_filename", "sneaker.jpg"),
+					resource.TestCheckResourceAttr("akeneo_product_media_file.test", "mime_type", "image/jpeg"),
+					resource.TestCheckResourceAttr("akeneo_product_media_file.test", "size", "13"),
+					resource.TestCheckResourceAttr("akeneo_product_media_file.test", "extension", "jpg"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProductMediaFileResource_unknownFile(t *testing.T) {
+	server := testAccServer(t)
+
+	image := filepath.Join(t.TempDir(), "sneaker.jpg")
+	if err := os.WriteFile(image, []byte("image content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The id of terraform_data is only known after apply, the empty
+			// substring of it makes the path unknown during the plan, so the
+			// file is hashed on create.
+			{
+				Config: testAccProviderConfig(server, `
+resource "terraform_data" "image" {}
+
+resource "akeneo_product_media_file" "test" {
+  file = "`+filepath.ToSlash(image)+`${substr(terraform_data.image.id, 0, 0)}"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akeneo_product_media_file.test", "code"),
+					resource.TestCheckResourceAttr("akeneo_product_media_file.test", "file_sha256", "b78f9dfd81d9bc073cad0a0e3acb1d6b164ede188bd71beb775b8004d7237117"),
+				),
+			},
+		},
+	})
+}
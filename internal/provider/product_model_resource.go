@@ -0,0 +1,404 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/typesx"
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProductModelResource{}
+var _ resource.ResourceWithImportState = &ProductModelResource{}
+var _ resource.ResourceWithConfigure = &ProductModelResource{}
+var _ resource.ResourceWithModifyPlan = &ProductModelResource{}
+
+func NewProductModelResource() resource.Resource {
+	return &ProductModelResource{}
+}
+
+// ProductModelResource defines the resource implementation.
+type ProductModelResource struct {
+	client       *akeneox.ProductModelService
+	familyClient *akeneox.FamilyService
+}
+
+// ProductModelResourceModel describes the resource data model.
+type ProductModelResourceModel struct {
+	Code          types.String        `tfsdk:"code"`
+	Family        types.String        `tfsdk:"family"`
+	FamilyVariant types.String        `tfsdk:"family_variant"`
+	Parent        types.String        `tfsdk:"parent"`
+	Categories    types.Set           `tfsdk:"categories"`
+	Values        []ProductValueModel `tfsdk:"values"`
+}
+
+func (r *ProductModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product_model"
+}
+
+func (r *ProductModelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Akeneo product model resource",
+
+		Attributes: map[string]schema.Attribute{
+			"code": schema.StringAttribute{
+				Description: "Product model code",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"family": schema.StringAttribute{
+				Description: "Code of the family the family variant belongs to",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"family_variant": schema.StringAttribute{
+				Description: "Family variant code defining the structure of the product model",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent": schema.StringAttribute{
+				Description: "Code of the parent product model when the product model is a sub product model",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"categories": schema.SetAttribute{
+				Description: "Codes of the categories in which the product model is classified",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"values": productValuesSchema("Product model attribute values. Only attributes of the product model enrichment level are allowed."),
+		},
+	}
+}
+
+func (r *ProductModelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	if data.Client == nil {
+		resp.Diagnostics.AddError(
+			"Missing client instance",
+			"Client instance pointer passed to Configure is required, got nil",
+		)
+		return
+	}
+
+	r.client = akeneox.NewProductModelClient(data.Client)
+	r.familyClient = akeneox.NewFamilyClient(data.Client)
+}
+
+func (r *ProductModelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.familyClient == nil {
+		return
+	}
+
+	var data ProductModelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Family.IsUnknown() || data.FamilyVariant.IsUnknown() || data.Parent.IsUnknown() {
+		return
+	}
+
+	variant, err := r.familyClient.GetFamilyVariant(data.Family.ValueString(), data.FamilyVariant.ValueString())
+	if err != nil {
+		// The family variant may be created within the same apply.
+		if akeneox.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error while reading a family variant",
+			"An unexpected error occurred when reading family variant of the product model. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	r.validateValueLevels(&resp.Diagnostics, &data, variant)
+}
+
+// validateValueLevels checks that every value belongs to the enrichment level of the
+// product model. Root product models hold the common attributes, sub product models
+// hold the attributes of the first variant attribute set.
+func (r *ProductModelResource) validateValueLevels(diags *diag.Diagnostics, data *ProductModelResourceModel, variant *goakeneo.FamilyVariant) {
+	levels := make(map[string]int)
+	maxLevel := 0
+	for _, set := range variant.VariantAttributeSets {
+		for _, a := range set.Axes {
+			levels[a] = set.Level
+		}
+		for _, a := range set.Attributes {
+			levels[a] = set.Level
+		}
+		if set.Level > maxLevel {
+			maxLevel = set.Level
+		}
+	}
+
+	level := 0
+	if !data.Parent.IsNull() {
+		level = 1
+		if maxLevel < 2 {
+			diags.AddAttributeError(
+				path.Root("parent"),
+				"Sub product models are not allowed",
+				fmt.Sprintf("Family variant %q has only one level of variation, so its product models cannot have a parent.", variant.Code),
+			)
+			return
+		}
+	}
+
+	for _, v := range data.Values {
+		if v.Attribute.IsUnknown() {
+			continue
+		}
+
+		attribute := v.Attribute.ValueString()
+		attrLevel := levels[attribute]
+		if attrLevel == level {
+			continue
+		}
+
+		var detail string
+		if attrLevel == 0 {
+			detail = fmt.Sprintf("Attribute %q is a common attribute of family variant %q and can only be set on the root product model.", attribute, variant.Code)
+		} else if attrLevel > 1 {
+			detail = fmt.Sprintf("Attribute %q belongs to enrichment level %d of family variant %q and can only be set on variant products.", attribute, attrLevel, variant.Code)
+		} else {
+			detail = fmt.Sprintf("Attribute %q belongs to enrichment level %d of family variant %q and can only be set on sub product models.", attribute, attrLevel, variant.Code)
+		}
+
+		diags.AddAttributeError(
+			productValuePath(v),
+			"Attribute value does not belong to the product model level",
+			detail,
+		)
+	}
+}
+
+func (r *ProductModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProductModelResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateProductModel(*apiData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while creating a product model",
+			"An unexpected error occurred when creating product model. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProductModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProductModelResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData, err := r.client.GetProductModel(data.Code.ValueString())
+	if err != nil {
+		if akeneox.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error while reading a product model",
+			"An unexpected error occurred when reading product model. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.mapToTfObject(&resp.Diagnostics, &data, apiData)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProductModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ProductModelResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiData := r.mapToApiObject(ctx, &resp.Diagnostics, &data)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// PATCH merges values, so values removed from the configuration have to be emptied explicitly.
+	planned := make(map[string]bool, len(data.Values))
+	for _, v := range data.Values {
+		planned[v.Attribute.ValueString()+"|"+v.Locale.ValueString()+"|"+v.Scope.ValueString()] = true
+	}
+	for _, v := range state.Values {
+		if planned[v.Attribute.ValueString()+"|"+v.Locale.ValueString()+"|"+v.Scope.ValueString()] {
+			continue
+		}
+		if apiData.Values == nil {
+			apiData.Values = make(map[string][]goakeneo.ProductValue)
+		}
+		attribute := v.Attribute.ValueString()
+		apiData.Values[attribute] = append(apiData.Values[attribute], goakeneo.ProductValue{
+			Locale: v.Locale.ValueStringPointer(),
+			Scope:  v.Scope.ValueStringPointer(),
+			Data:   nil,
+		})
+	}
+
+	err := r.client.UpdateProductModel(*apiData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error while updating a product model",
+			"An unexpected error occurred when updating product model. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProductModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ProductModelResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteProductModel(data.Code.ValueString())
+	if err != nil && !akeneox.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error while deleting a product model",
+			"An unexpected error occurred when deleting product model. \n\n"+
+				"Akeneo API Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *ProductModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("code"), req, resp)
+}
+
+// mapToApiObject always sets the parent and the categories, PATCH requests
+// would keep them otherwise when they are removed from the configuration.
+func (r *ProductModelResource) mapToApiObject(ctx context.Context, diags *diag.Diagnostics, data *ProductModelResourceModel) *akeneox.ProductModel {
+	a := akeneox.ProductModel{
+		Code:          data.Code.ValueString(),
+		Family:        data.Family.ValueString(),
+		FamilyVariant: data.FamilyVariant.ValueString(),
+		Parent:        data.Parent.ValueStringPointer(),
+		Categories:    []string{},
+	}
+
+	a.Categories = append(a.Categories, typesx.SetElements[string](ctx, diags, data.Categories)...)
+
+	a.Values = productValuesToApi(diags, data.Values)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	return &a
+}
+
+func (r *ProductModelResource) mapToTfObject(respDiags *diag.Diagnostics, data *ProductModelResourceModel, apiData *goakeneo.ProductModel) {
+	data.Code = types.StringValue(apiData.Code)
+	data.FamilyVariant = types.StringValue(apiData.FamilyVariant)
+
+	if apiData.Family != "" {
+		data.Family = types.StringValue(apiData.Family)
+	}
+
+	if apiData.Parent != "" {
+		data.Parent = types.StringValue(apiData.Parent)
+	} else {
+		data.Parent = types.StringNull()
+	}
+
+	data.Categories = typesx.SetValue(respDiags, apiData.Categories)
+
+	data.Values = productValuesFromApi(respDiags, data.Values, apiData.Values)
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProductModelResource(t *testing.T) {
+	server := testAccServer(t)
+
+	variantConfig := testAccFamilyVariantFamilyConfig + `
+resource "akeneo_family_variant" "shoes" {
+  family_code = akeneo_family.shoes.code
+  code        = "shoes_by_color_size"
+
+  variant_attribute_sets = {
+    "1" = { axes = [akeneo_attribute.color.code] }
+    "2" = { axes = [akeneo_attribute.size.code] }
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server, variantConfig+`
+resource "akeneo_product_model" "root" {
+  code           = "sneaker"
+  family         = akeneo_family.shoes.code
+  family_variant = akeneo_family_variant.shoes.code
+  categories     = ["master"]
+
+  values = [
+    { attribute = "name", data = jsonencode("Sneaker") },
+  ]
+}
+
+resource "akeneo_product_model" "red" {
+  code           = "sneaker_red"
+  family         = akeneo_family.shoes.code
+  family_variant = akeneo_family_variant.shoes.code
+  parent         = akeneo_product_model.root.code
+
+  values = [
+    { attribute = "color", data = jsonencode("red") },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_product_model.root", "code", "sneaker"),
+					resource.TestCheckResourceAttr("akeneo_product_model.root", "values.#", "1"),
+					resource.TestCheckResourceAttr("akeneo_product_model.red", "parent", "sneaker"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "akeneo_product_model.root",
+				ImportState:                          true,
+				ImportStateId:                        "sneaker",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "code",
+				// Only the values of the configuration are managed, so imports have none.
+				ImportStateVerifyIgnore: []string{"values"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server, variantConfig+`
+resource "akeneo_product_model" "root" {
+  code           = "sneaker"
+  family         = akeneo_family.shoes.code
+  family_variant = akeneo_family_variant.shoes.code
+}
+
+resource "akeneo_product_model" "red" {
+  code           = "sneaker_red"
+  family         = akeneo_family.shoes.code
+  family_variant = akeneo_family_variant.shoes.code
+  parent         = akeneo_product_model.root.code
+
+  values = [
+    { attribute = "color", data = jsonencode("red") },
+  ]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("akeneo_product_model.root", "values.#"),
+					resource.TestCheckNoResourceAttr("akeneo_product_model.root", "categories.#"),
+				),
+			},
+			// Product models are deleted, the catalog objects are only removed from the state.
+			{
+				Config: testAccRemovedConfig(server,
+					"akeneo_attribute.color", "akeneo_attribute.size", "akeneo_attribute.name",
+					"akeneo_family.shoes", "akeneo_family_variant.shoes",
+				),
+			},
+		},
+	})
+}
+
+func TestProductModelMapToApiObject(t *testing.T) {
+	cases := []struct {
+		name       string
+		parent     types.String
+		categories types.Set
+		expected   string
+	}{
+		{
+			name:       "parent and categories",
+			parent:     types.StringValue("tshirt"),
+			categories: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("summer")}),
+			expected:   `{"code":"tshirt_red","family":"clothing","family_variant":"clothing_color","parent":"tshirt","categories":["summer"]}`,
+		},
+		{
+			name:       "removed parent and categories",
+			parent:     types.StringNull(),
+			categories: types.SetNull(types.StringType),
+			expected:   `{"code":"tshirt_red","family":"clothing","family_variant":"clothing_color","parent":null,"categories":[]}`,
+		},
+		{
+			name:       "empty categories",
+			parent:     types.StringNull(),
+			categories: types.SetValueMust(types.StringType, []attr.Value{}),
+			expected:   `{"code":"tshirt_red","family":"clothing","family_variant":"clothing_color","parent":null,"categories":[]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			apiData := (&ProductModelResource{}).mapToApiObject(context.Background(), &diags, &ProductModelResourceModel{
+				Code:          types.StringValue("tshirt_red"),
+				Family:        types.StringValue("clothing"),
+				FamilyVariant: types.StringValue("clothing_color"),
+				Parent:        c.parent,
+				Categories:    c.categories,
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			b, err := json.Marshal(apiData)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(b) != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, b)
+			}
+		})
+	}
+}
+
+func TestProductModelMapToTfObject(t *testing.T) {
+	cases := []struct {
+		name       string
+		categories types.Set
+		apiData    goakeneo.ProductModel
+		expected   types.Set
+	}{
+		{
+			name:       "categories removed outside of terraform",
+			categories: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("summer")}),
+			expected:   types.SetNull(types.StringType),
+		},
+		{
+			name:       "no categories",
+			categories: types.SetNull(types.StringType),
+			expected:   types.SetNull(types.StringType),
+		},
+		{
+			name:       "categories",
+			categories: types.SetNull(types.StringType),
+			apiData:    goakeneo.ProductModel{Categories: []string{"summer"}},
+			expected:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("summer")}),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			data := ProductModelResourceModel{Categories: c.categories}
+			c.apiData.Code = "tshirt_red"
+			c.apiData.Parent = "tshirt"
+
+			(&ProductModelResource{}).mapToTfObject(&diags, &data, &c.apiData)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !data.Categories.Equal(c.expected) {
+				t.Errorf("expected categories %s, got %s", c.expected, data.Categories)
+			}
+			if data.Parent.ValueString() != "tshirt" {
+				t.Errorf("expected parent tshirt, got %s", data.Parent)
+			}
+		})
+	}
+}
+
+func TestProductModelValidateValueLevels(t *testing.T) {
+	variant := &goakeneo.FamilyVariant{
+		Code: "clothing_color",
+		VariantAttributeSets: []goakeneo.VariantAttributeSet{
+			{Level: 1, Axes: []string{"color"}, Attributes: []string{"color", "image"}},
+		},
+	}
+	name := ProductValueModel{
+		Attribute: types.StringValue("name"),
+		Locale:    types.StringNull(),
+		Scope:     types.StringNull(),
+		Data:      types.StringValue(`"T-shirt"`),
+	}
+	image := ProductValueModel{
+		Attribute: types.StringValue("image"),
+		Locale:    types.StringNull(),
+		Scope:     types.StringNull(),
+		Data:      types.StringValue(`"a/b/c/image.jpg"`),
+	}
+
+	var diags diag.Diagnostics
+	(&ProductModelResource{}).validateValueLevels(&diags, &ProductModelResourceModel{
+		Parent: types.StringNull(),
+		Values: []ProductValueModel{name, image},
+	}, variant)
+
+	if len(diags) != 1 {
+		t.Fatalf("expected a single diagnostic, got %v", diags)
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok {
+		t.Fatalf("expected a diagnostic with a path, got %v", diags[0])
+	}
+	if expected := productValuePath(image); !withPath.Path().Equal(expected) {
+		t.Errorf("expected path %s, got %s", expected, withPath.Path())
+	}
+}
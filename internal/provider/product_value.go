@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProductValueModel describes a single attribute value in the Akeneo product value format.
+type ProductValueModel struct {
+	Attribute types.String `tfsdk:"attribute"`
+	Locale    types.String `tfsdk:"locale"`
+	Scope     types.String `tfsdk:"scope"`
+	Data      types.String `tfsdk:"data"`
+}
+
+// productValuePath returns the path of the value in the values set, so that
+// diagnostics point to the value instead of the whole set.
+func productValuePath(v ProductValueModel) path.Path {
+	value := types.ObjectValueMust(
+		map[string]attr.Type{
+			"attribute": types.StringType,
+			"locale":    types.StringType,
+			"scope":     types.StringType,
+			"data":      types.StringType,
+		},
+		map[string]attr.Value{
+			"attribute": v.Attribute,
+			"locale":    v.Locale,
+			"scope":     v.Scope,
+			"data":      v.Data,
+		},
+	)
+	return path.Root("values").AtSetValue(value)
+}
+
+func productValuesSchema(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Description: description,
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"attribute": schema.StringAttribute{
+					Description: "Attribute code",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"locale": schema.StringAttribute{
+					Description: "Locale of the value when the attribute is localizable",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidatorx.IsLocaleCode(),
+					},
+				},
+				"scope": schema.StringAttribute{
+					Description: "Channel code of the value when the attribute is scopable",
+					Optional:    true,
+				},
+				"data": schema.StringAttribute{
+					Description: "JSON encoded value data, see the Akeneo product value format. Example: jsonencode({ amount = 10, unit = \"KILOGRAM\" })",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidatorx.IsJSON(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func productValuesToApi(diags *diag.Diagnostics, values []ProductValueModel) map[string][]goakeneo.ProductValue {
+	if values == nil {
+		return nil
+	}
+
+	result := make(map[string][]goakeneo.ProductValue)
+	for _, v := range values {
+		var data any
+		if err := json.Unmarshal([]byte(v.Data.ValueString()), &data); err != nil {
+			diags.AddError(
+				"Invalid value data",
+				"Value data of attribute "+v.Attribute.ValueString()+" is not a valid JSON document. \n\n"+
+					"Error: "+err.Error(),
+			)
+			continue
+		}
+
+		attribute := v.Attribute.ValueString()
+		result[attribute] = append(result[attribute], goakeneo.ProductValue{
+			Locale: v.Locale.ValueStringPointer(),
+			Scope:  v.Scope.ValueStringPointer(),
+			Data:   data,
+		})
+	}
+
+	return result
+}
+
+// productValuesFromApi maps api values back to the model. Only attributes of the
+// prior values are kept, as the API also returns values the resource does not
+// manage, and semantically equal data keeps its configured JSON formatting.
+func productValuesFromApi(diags *diag.Diagnostics, prior []ProductValueModel, values map[string][]goakeneo.ProductValue) []ProductValueModel {
+	if prior == nil {
+		return nil
+	}
+
+	managed := make(map[string]bool, len(prior))
+	for _, v := range prior {
+		managed[v.Attribute.ValueString()] = true
+	}
+
+	result := make([]ProductValueModel, 0)
+	for attribute, attrValues := range values {
+		if !managed[attribute] {
+			continue
+		}
+
+		for _, v := range attrValues {
+			data, err := json.Marshal(v.Data)
+			if err != nil {
+				diags.AddError(
+					"Error encoding value data",
+					"Value data of attribute "+attribute+" could not be encoded. \n\n"+
+						"Error: "+err.Error(),
+				)
+				continue
+			}
+
+			m := ProductValueModel{
+				Attribute: types.StringValue(attribute),
+				Locale:    types.StringPointerValue(v.Locale),
+				Scope:     types.StringPointerValue(v.Scope),
+				Data:      types.StringValue(string(data)),
+			}
+
+			for _, p := range prior {
+				if p.Attribute.Equal(m.Attribute) && p.Locale.Equal(m.Locale) && p.Scope.Equal(m.Scope) && jsonEqual(p.Data.ValueString(), v.Data) {
+					m.Data = p.Data
+					break
+				}
+			}
+
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+func jsonEqual(encoded string, value any) bool {
+	var decoded any
+	if err := json.Unmarshal([]byte(encoded), &decoded); err != nil {
+		return false
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	var other any
+	if err := json.Unmarshal(normalized, &other); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(decoded, other)
+}
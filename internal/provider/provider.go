@@ -3,11 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox"
+	"github.com/0xfrej/terraform-provider-akeneo/internal/validator/stringvalidatorx"
 	goakeneo "github.com/ezifyio/go-akeneo"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,6 +24,27 @@ import (
 
 // Ensure AkeneoProvider satisfies various provider interfaces.
 var _ provider.Provider = &AkeneoProvider{}
+var _ provider.ProviderWithFunctions = &AkeneoProvider{}
+
+const (
+	tokenCacheMemory = "memory"
+	tokenCacheFile   = "file"
+)
+
+// passwordGrantPaths are the attributes of the password grant authentication,
+// which is used unless an access token is configured.
+var passwordGrantPaths = []path.Expression{
+	path.MatchRoot("api_client_id"),
+	path.MatchRoot("api_client_secret"),
+	path.MatchRoot("api_username"),
+	path.MatchRoot("api_password"),
+}
+
+// memoryTokenCache keeps the tokens for the lifetime of the provider process.
+// Terraform runs every provider configuration, including aliases, in its own
+// process, so the tokens are only reused when a process configures the
+// provider again.
+var memoryTokenCache = akeneox.NewMemoryTokenCache()
 
 // AkeneoProvider defines the provider implementation.
 type AkeneoProvider struct {
@@ -31,15 +59,29 @@ type AkeneoProviderModel struct {
 	ApiPassword         types.String `tfsdk:"api_password"`
 	ApiClientId         types.String `tfsdk:"api_client_id"`
 	ApiSecret           types.String `tfsdk:"api_client_secret"`
+	AccessToken         types.String `tfsdk:"access_token"`
 	ExtraAttributeTypes types.List   `tfsdk:"extra_attribute_types"`
+	DefaultLabelLocales types.List   `tfsdk:"default_label_locales"`
+	TokenCache          types.String `tfsdk:"token_cache"`
+	TokenCacheDir       types.String `tfsdk:"token_cache_dir"`
+	TokenCacheKey       types.String `tfsdk:"token_cache_key"`
 }
 
 type DataSourceData struct {
-	Client *goakeneo.Client
+	Client *akeneox.Client
 }
 
 type ResourceData struct {
-	Client *goakeneo.Client
+	Client *akeneox.Client
+	// MeasurementFamilies is shared by all resources so the measurement
+	// families are only downloaded once per provider run.
+	MeasurementFamilies *akeneox.MeasurementFamilyStore
+	// Catalog is shared by all resources so plan time checks only list the
+	// channels, attributes and families once per provider run.
+	Catalog *akeneox.CatalogSnapshot
+	// DefaultLabelLocales are the locales a single label of a resource is
+	// expanded to.
+	DefaultLabelLocales []string
 }
 
 func (p *AkeneoProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -60,25 +102,34 @@ func (p *AkeneoProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 			},
 			"api_username": schema.StringAttribute{
-				MarkdownDescription: "Akeneo API client username",
-				Required:            true,
+				MarkdownDescription: "Akeneo API client username, required unless `access_token` is set",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"api_password": schema.StringAttribute{
-				MarkdownDescription: "Akeneo API client password",
-				Required:            true,
+				MarkdownDescription: "Akeneo API client password, required unless `access_token` is set",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"api_client_id": schema.StringAttribute{
-				MarkdownDescription: "Akeneo API client ID",
-				Required:            true,
+				MarkdownDescription: "Akeneo API client ID, required unless `access_token` is set",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"api_client_secret": schema.StringAttribute{
-				MarkdownDescription: "Akeneo API client secret",
-				Required:            true,
+				MarkdownDescription: "Akeneo API client secret, required unless `access_token` is set",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Pre-issued Akeneo API access token, e.g. of a connected app, used instead of the API client credentials",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(passwordGrantPaths...),
+				},
+			},
 			"extra_attribute_types": schema.ListAttribute{
 				MarkdownDescription: "Extra attribute types that are not supported by default",
 				Optional:            true,
@@ -89,6 +140,44 @@ func (p *AkeneoProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					),
 				},
 			},
+			"default_label_locales": schema.ListAttribute{
+				MarkdownDescription: "Locales the `label` attribute of resources, or a single label in `labels`, is used for. Labels set per locale take precedence",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidatorx.IsLocaleCode(),
+					),
+				},
+			},
+			"token_cache": schema.StringAttribute{
+				MarkdownDescription: "Cache of the API tokens of the same host, API client ID and username, so the provider does not authenticate again. " +
+					"`memory` keeps the tokens in the provider process only. Terraform starts a separate provider process for every provider configuration and command, " +
+					"so use `file` to share the tokens between aliased providers and across commands in encrypted files",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tokenCacheMemory, tokenCacheFile),
+					stringvalidator.ConflictsWith(path.MatchRoot("access_token")),
+				},
+			},
+			"token_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory of the `file` token cache, defaults to `terraform-provider-akeneo/tokens` in the user cache directory",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("token_cache")),
+				},
+			},
+			"token_cache_key": schema.StringAttribute{
+				MarkdownDescription: "Key the tokens of the `file` token cache are encrypted with",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("token_cache")),
+				},
+			},
 		},
 	}
 }
@@ -102,11 +191,9 @@ func (p *AkeneoProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	connector := goakeneo.Connector{
-		ClientID: data.ApiClientId.ValueString(),
-		Secret:   data.ApiSecret.ValueString(),
-		UserName: data.ApiUsername.ValueString(),
-		Password: data.ApiPassword.ValueString(),
+	validateAuthentication(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	var proto string
@@ -115,12 +202,39 @@ func (p *AkeneoProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	} else {
 		proto = "https"
 	}
+	baseURL := fmt.Sprintf("%s://%s", proto, data.Host.ValueString())
 
-	opts := []goakeneo.Option{
-		goakeneo.WithBaseURL(fmt.Sprintf("%s://%s", proto, data.Host.ValueString())),
-	}
+	var client *akeneox.Client
+	var err error
+	if !data.AccessToken.IsNull() {
+		client, err = akeneox.NewClientWithAccessToken(data.AccessToken.ValueString(), baseURL)
+	} else {
+		connector := goakeneo.Connector{
+			ClientID: data.ApiClientId.ValueString(),
+			Secret:   data.ApiSecret.ValueString(),
+			UserName: data.ApiUsername.ValueString(),
+			Password: data.ApiPassword.ValueString(),
+		}
 
-	client, err := goakeneo.NewClient(connector, opts...)
+		var opts []akeneox.Option
+		switch data.TokenCache.ValueString() {
+		case tokenCacheMemory:
+			opts = append(opts, akeneox.WithTokenCache(memoryTokenCache))
+		case tokenCacheFile:
+			cache, err := newFileTokenCache(data)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("token_cache"),
+					"Unable to create token cache",
+					"The file token cache can not be used: "+err.Error(),
+				)
+				return
+			}
+			opts = append(opts, akeneox.WithTokenCache(cache))
+		}
+
+		client, err = akeneox.NewClient(connector, baseURL, opts...)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create client",
@@ -132,30 +246,126 @@ func (p *AkeneoProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 	//TODO: add akeneo version validation - support 6, 7
 
+	var defaultLabelLocales []string
+	if !data.DefaultLabelLocales.IsNull() {
+		resp.Diagnostics.Append(data.DefaultLabelLocales.ElementsAs(ctx, &defaultLabelLocales, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.DataSourceData = &DataSourceData{
 		Client: client,
 	}
 	resp.ResourceData = &ResourceData{
-		Client: client,
+		Client:              client,
+		MeasurementFamilies: akeneox.NewMeasurementFamilyStore(client),
+		Catalog:             akeneox.NewCatalogSnapshot(client),
+		DefaultLabelLocales: defaultLabelLocales,
 	}
 }
 
+// validateAuthentication checks that exactly one authentication mode is
+// configured: the access token, or all attributes of the password grant.
+func validateAuthentication(data AkeneoProviderModel, diags *diag.Diagnostics) {
+	passwordGrant := map[string]types.String{
+		"api_client_id":     data.ApiClientId,
+		"api_client_secret": data.ApiSecret,
+		"api_username":      data.ApiUsername,
+		"api_password":      data.ApiPassword,
+	}
+	names := []string{"api_client_id", "api_client_secret", "api_username", "api_password"}
+
+	if !data.AccessToken.IsNull() {
+		for _, name := range names {
+			if !passwordGrant[name].IsNull() {
+				diags.AddAttributeError(
+					path.Root(name),
+					"Conflicting Akeneo API authentication",
+					fmt.Sprintf("The %s attribute can not be set together with access_token, configure either the access token or the API client credentials.", name),
+				)
+			}
+		}
+		return
+	}
+
+	set := 0
+	for _, value := range passwordGrant {
+		if !value.IsNull() {
+			set++
+		}
+	}
+	if set == 0 {
+		diags.AddError(
+			"Missing Akeneo API authentication",
+			"Configure either access_token, or api_client_id, api_client_secret, api_username and api_password.",
+		)
+		return
+	}
+
+	for _, name := range names {
+		if passwordGrant[name].IsNull() {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Missing Akeneo API client credentials",
+				fmt.Sprintf("The %s attribute is required when access_token is not set.", name),
+			)
+		}
+	}
+}
+
+// newFileTokenCache creates the file token cache of the configuration.
+func newFileTokenCache(data AkeneoProviderModel) (*akeneox.FileTokenCache, error) {
+	if data.TokenCacheKey.ValueString() == "" {
+		return nil, fmt.Errorf("token_cache_key is required")
+	}
+
+	dir := data.TokenCacheDir.ValueString()
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("set token_cache_dir, the user cache directory is unknown: %w", err)
+		}
+		dir = filepath.Join(cacheDir, "terraform-provider-akeneo", "tokens")
+	}
+
+	return akeneox.NewFileTokenCache(dir, data.TokenCacheKey.ValueString())
+}
+
 func (p *AkeneoProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAttributeResource,
 		NewAttributeOptionResource,
+		NewAttributeOptionsResource,
 		NewAttributeGroupResource,
 		NewFamilyResource,
 		NewFamilyVariantResource,
 		NewMeasurementFamilyResource,
 		NewChannelResource,
 		NewCategoryResource,
+		NewCategoryTemplateResource,
 		NewAssociationTypeResource,
+		NewProductModelResource,
+		NewProductMediaFileResource,
 	}
 }
 
 func (p *AkeneoProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewCategoryTreeDataSource,
+		NewAssociationTypesDataSource,
+	}
+}
+
+func (p *AkeneoProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewLabelsFunction,
+		NewNormalizeCodeFunction,
+		NewLocaleValidFunction,
+		NewConversionOperationsFunction,
+		NewConversionFormulaFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
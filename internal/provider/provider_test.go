@@ -1,22 +1,193 @@
 package provider
 
-// import (
-// 	"testing"
-
-// 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-// 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-// )
-
-// // testAccProtoV6ProviderFactories are used to instantiate a provider during
-// // acceptance testing. The factory function will be invoked for every Terraform
-// // CLI command executed to create a provider server to which the CLI can
-// // reattach.
-// var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-// 	"akeneox": providerserver.NewProtocol6WithError(New("test")()),
-// }
-
-// func testAccPreCheck(t *testing.T) {
-// 	// You can add code here to run prior to any test case execution, for example assertions
-// 	// about the appropriate environment variables being set are common to see in a pre-check
-// 	// function.
-// }
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/0xfrej/terraform-provider-akeneo/internal/akeneox/akeneoxtest"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"akeneo": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+func testAccPreCheck(t *testing.T) {
+	// The acceptance tests run against the fake Akeneo PIM of akeneoxtest, so
+	// no credentials have to be set in the environment.
+}
+
+// testAccServer starts a fake Akeneo PIM for the duration of the test.
+func testAccServer(t *testing.T) *akeneoxtest.Server {
+	t.Helper()
+
+	server := akeneoxtest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testAccProviderConfig returns the provider configuration for the server
+// followed by the given configuration.
+func testAccProviderConfig(server *akeneoxtest.Server, config string) string {
+	return testAccProviderConfigWithArguments(server, "", config)
+}
+
+// testAccProviderConfigWithArguments returns the provider configuration for
+// the server with additional provider arguments, e.g. default_label_locales.
+func testAccProviderConfigWithArguments(server *akeneoxtest.Server, arguments string, config string) string {
+	return fmt.Sprintf(`
+provider "akeneo" {
+  host              = %q
+  unsecure_api      = true
+  api_username      = %q
+  api_password      = %q
+  api_client_id     = %q
+  api_client_secret = %q
+%s
+}
+`, server.Host(), akeneoxtest.Username, akeneoxtest.Password, akeneoxtest.ClientID, akeneoxtest.Secret, arguments) + config
+}
+
+// testAccRemovedConfig removes the resources from the state without destroying
+// them. Most Akeneo objects can not be deleted through the API, so the last
+// step of a test uses it to leave nothing for the destroy at the end of the test.
+func testAccRemovedConfig(server *akeneoxtest.Server, addresses ...string) string {
+	var b strings.Builder
+	for _, address := range addresses {
+		fmt.Fprintf(&b, `
+removed {
+  from = %s
+
+  lifecycle {
+    destroy = false
+  }
+}
+`, address)
+	}
+	return testAccProviderConfig(server, b.String())
+}
+
+func TestAccProvider_tokenCache(t *testing.T) {
+	server := testAccServer(t)
+	arguments := fmt.Sprintf(`
+  token_cache     = "file"
+  token_cache_dir = %q
+  token_cache_key = "key"
+`, t.TempDir())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfigWithArguments(server, arguments, `
+resource "akeneo_attribute_group" "test" {
+  code   = "marketing"
+  labels = { en_US = "Marketing" }
+}
+`),
+				// Terraform configures the provider for every command, all of
+				// them use the token of the first one.
+				Check: func(*terraform.State) error {
+					if grants := server.Grants(); grants != 1 {
+						return fmt.Errorf("expected 1 granted token, got %d", grants)
+					}
+					return nil
+				},
+			},
+			{
+				Config: testAccRemovedConfig(server, "akeneo_attribute_group.test"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_accessToken(t *testing.T) {
+	server := testAccServer(t)
+	provider := fmt.Sprintf(`
+provider "akeneo" {
+  host         = %q
+  unsecure_api = true
+  access_token = %q
+}
+`, server.Host(), akeneoxtest.AccessToken)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: provider + `
+resource "akeneo_attribute_group" "test" {
+  code   = "marketing"
+  labels = { en_US = "Marketing" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akeneo_attribute_group.test", "labels.en_US", "Marketing"),
+					func(*terraform.State) error {
+						if grants := server.Grants(); grants != 0 {
+							return fmt.Errorf("expected no granted token, got %d", grants)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: provider + `
+removed {
+  from = akeneo_attribute_group.test
+
+  lifecycle {
+    destroy = false
+  }
+}
+`,
+			},
+		},
+	})
+}
+
+func TestAccProvider_authentication(t *testing.T) {
+	server := testAccServer(t)
+	config := `
+data "akeneo_association_types" "test" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfigWithArguments(server, fmt.Sprintf("access_token = %q", akeneoxtest.AccessToken), config),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "akeneo" {
+  host          = %q
+  api_client_id = %q
+}
+`, server.Host(), akeneoxtest.ClientID) + config,
+				ExpectError: regexp.MustCompile(`The api_password attribute is required when access_token is not set`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "akeneo" {
+  host = %q
+}
+`, server.Host()) + config,
+				ExpectError: regexp.MustCompile(`Missing Akeneo API authentication`),
+			},
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringListToSet converts a string list of a prior state into a set, dropping
+// duplicate elements which a set can not hold.
+func stringListToSet(list types.List) (types.Set, diag.Diagnostics) {
+	if list.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+	if list.IsUnknown() {
+		return types.SetUnknown(types.StringType), nil
+	}
+
+	seen := make(map[string]bool, len(list.Elements()))
+	elements := make([]attr.Value, 0, len(list.Elements()))
+	for _, v := range list.Elements() {
+		s, ok := v.(types.String)
+		if ok && !s.IsNull() && !s.IsUnknown() {
+			if seen[s.ValueString()] {
+				continue
+			}
+			seen[s.ValueString()] = true
+		}
+		elements = append(elements, v)
+	}
+
+	return types.SetValue(types.StringType, elements)
+}
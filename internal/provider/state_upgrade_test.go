@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// testUpgradeState upgrades the JSON state a prior schema version of the
+// resource wrote, through the provider server as Terraform does, and reads the
+// upgraded state into target.
+func testUpgradeState(t *testing.T, r resource.Resource, version int64, state string, target any) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	metadata := resource.MetadataResponse{}
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "akeneo"}, &metadata)
+
+	schema := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schema)
+
+	server := providerserver.NewProtocol6(New("test")())()
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: metadata.TypeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatalf("upgrading state: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("upgrading state: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	raw, err := resp.UpgradedState.Unmarshal(schema.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("decoding upgraded state: %s", err)
+	}
+
+	upgraded := tfsdk.State{Schema: schema.Schema, Raw: raw}
+	if diags := upgraded.Get(ctx, target); diags.HasError() {
+		t.Fatalf("reading upgraded state: %v", diags)
+	}
+}
+
+// testStringSet returns a known string set of the values.
+func testStringSet(values ...string) types.Set {
+	elements := make([]attr.Value, len(values))
+	for i, v := range values {
+		elements[i] = types.StringValue(v)
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+func TestStringListToSet(t *testing.T) {
+	list := func(values ...string) types.List {
+		elements := make([]attr.Value, len(values))
+		for i, v := range values {
+			elements[i] = types.StringValue(v)
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	cases := []struct {
+		name     string
+		list     types.List
+		expected types.Set
+	}{
+		{name: "null", list: types.ListNull(types.StringType), expected: types.SetNull(types.StringType)},
+		{name: "unknown", list: types.ListUnknown(types.StringType), expected: types.SetUnknown(types.StringType)},
+		{name: "empty", list: list(), expected: testStringSet()},
+		{name: "elements", list: list("en_US", "de_DE"), expected: testStringSet("de_DE", "en_US")},
+		{name: "duplicates", list: list("en_US", "de_DE", "en_US"), expected: testStringSet("en_US", "de_DE")},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			set, diags := stringListToSet(c.list)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !set.Equal(c.expected) {
+				t.Errorf("expected %s, got %s", c.expected, set)
+			}
+		})
+	}
+}
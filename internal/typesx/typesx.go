@@ -0,0 +1,164 @@
+// Package typesx converts Terraform collection values from and to Go values.
+//
+// Null, unknown and empty collections are handled alike: they have no
+// elements, and no elements are converted into a null collection, so empty
+// values are absent in the state. Conversion errors are appended to diags as
+// done by the mappers of the resources.
+package typesx
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Primitive is a Go type with a Terraform primitive counterpart.
+type Primitive interface {
+	string | bool | int64 | float64
+}
+
+// MapElements returns the elements of a map, nil for a null, unknown or empty map.
+func MapElements[T any](ctx context.Context, diags *diag.Diagnostics, v types.Map) map[string]T {
+	if v.IsNull() || v.IsUnknown() || len(v.Elements()) == 0 {
+		return nil
+	}
+
+	elements := make(map[string]T, len(v.Elements()))
+	d := v.ElementsAs(ctx, &elements, false)
+	diags.Append(d...)
+	if d.HasError() {
+		return nil
+	}
+	return elements
+}
+
+// ListElements returns the elements of a list, nil for a null, unknown or empty list.
+func ListElements[T any](ctx context.Context, diags *diag.Diagnostics, v types.List) []T {
+	if v.IsNull() || v.IsUnknown() || len(v.Elements()) == 0 {
+		return nil
+	}
+
+	elements := make([]T, 0, len(v.Elements()))
+	d := v.ElementsAs(ctx, &elements, false)
+	diags.Append(d...)
+	if d.HasError() {
+		return nil
+	}
+	return elements
+}
+
+// SetElements returns the elements of a set, nil for a null, unknown or empty set.
+func SetElements[T any](ctx context.Context, diags *diag.Diagnostics, v types.Set) []T {
+	if v.IsNull() || v.IsUnknown() || len(v.Elements()) == 0 {
+		return nil
+	}
+
+	elements := make([]T, 0, len(v.Elements()))
+	d := v.ElementsAs(ctx, &elements, false)
+	diags.Append(d...)
+	if d.HasError() {
+		return nil
+	}
+	return elements
+}
+
+// MapValue returns a map of the elements, null when there are none.
+func MapValue[T Primitive](diags *diag.Diagnostics, elements map[string]T) types.Map {
+	if len(elements) == 0 {
+		return types.MapNull(elementType[T]())
+	}
+
+	values := make(map[string]attr.Value, len(elements))
+	for k, v := range elements {
+		values[k] = elementValue(v)
+	}
+	v, d := types.MapValue(elementType[T](), values)
+	diags.Append(d...)
+	return v
+}
+
+// ListValue returns a list of the elements, null when there are none.
+func ListValue[T Primitive](diags *diag.Diagnostics, elements []T) types.List {
+	if len(elements) == 0 {
+		return types.ListNull(elementType[T]())
+	}
+
+	values := make([]attr.Value, len(elements))
+	for i, v := range elements {
+		values[i] = elementValue(v)
+	}
+	v, d := types.ListValue(elementType[T](), values)
+	diags.Append(d...)
+	return v
+}
+
+// SetValue returns a set of the elements, null when there are none. Duplicate
+// elements are dropped as a set can not hold them.
+func SetValue[T Primitive](diags *diag.Diagnostics, elements []T) types.Set {
+	if len(elements) == 0 {
+		return types.SetNull(elementType[T]())
+	}
+
+	seen := make(map[T]bool, len(elements))
+	values := make([]attr.Value, 0, len(elements))
+	for _, v := range elements {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, elementValue(v))
+	}
+	v, d := types.SetValue(elementType[T](), values)
+	diags.Append(d...)
+	return v
+}
+
+// SetMapValue returns a map of sets of the elements, null when there are none.
+// Empty sets are left out of the map.
+func SetMapValue[T Primitive](diags *diag.Diagnostics, elements map[string][]T) types.Map {
+	setType := types.SetType{ElemType: elementType[T]()}
+
+	values := make(map[string]attr.Value, len(elements))
+	for k, v := range elements {
+		if len(v) > 0 {
+			values[k] = SetValue(diags, v)
+		}
+	}
+
+	if len(values) == 0 {
+		return types.MapNull(setType)
+	}
+
+	v, d := types.MapValue(setType, values)
+	diags.Append(d...)
+	return v
+}
+
+func elementType[T Primitive]() attr.Type {
+	var zero T
+	switch any(zero).(type) {
+	case bool:
+		return types.BoolType
+	case int64:
+		return types.Int64Type
+	case float64:
+		return types.Float64Type
+	default:
+		return types.StringType
+	}
+}
+
+func elementValue[T Primitive](v T) attr.Value {
+	switch v := any(v).(type) {
+	case bool:
+		return types.BoolValue(v)
+	case int64:
+		return types.Int64Value(v)
+	case float64:
+		return types.Float64Value(v)
+	default:
+		return types.StringValue(v.(string))
+	}
+}
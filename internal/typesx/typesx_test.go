@@ -0,0 +1,134 @@
+package typesx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestElementsOfNullUnknownAndEmpty(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	maps := []types.Map{
+		types.MapNull(types.StringType),
+		types.MapUnknown(types.StringType),
+		types.MapValueMust(types.StringType, map[string]attr.Value{}),
+	}
+	for _, v := range maps {
+		if got := MapElements[string](ctx, &diags, v); got != nil {
+			t.Errorf("expected no elements of %s, got %v", v, got)
+		}
+	}
+
+	sets := []types.Set{
+		types.SetNull(types.StringType),
+		types.SetUnknown(types.StringType),
+		types.SetValueMust(types.StringType, []attr.Value{}),
+	}
+	for _, v := range sets {
+		if got := SetElements[string](ctx, &diags, v); got != nil {
+			t.Errorf("expected no elements of %s, got %v", v, got)
+		}
+	}
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestElements(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	labels := MapElements[string](ctx, &diags, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"en_US": types.StringValue("Color"),
+	}))
+	if len(labels) != 1 || labels["en_US"] != "Color" {
+		t.Errorf("unexpected map elements %v", labels)
+	}
+
+	axes := ListElements[string](ctx, &diags, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("color"),
+		types.StringValue("size"),
+	}))
+	if len(axes) != 2 || axes[0] != "color" || axes[1] != "size" {
+		t.Errorf("unexpected list elements %v", axes)
+	}
+
+	requirements := MapElements[[]string](ctx, &diags, types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+		"ecommerce": types.SetValueMust(types.StringType, []attr.Value{types.StringValue("sku")}),
+	}))
+	if len(requirements["ecommerce"]) != 1 || requirements["ecommerce"][0] != "sku" {
+		t.Errorf("unexpected map of set elements %v", requirements)
+	}
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestValuesOfNoElementsAreNull(t *testing.T) {
+	var diags diag.Diagnostics
+
+	if v := MapValue(&diags, map[string]string{}); !v.IsNull() {
+		t.Errorf("expected null map, got %s", v)
+	}
+	if v := MapValue[string](&diags, nil); !v.IsNull() {
+		t.Errorf("expected null map, got %s", v)
+	}
+	if v := ListValue(&diags, []string{}); !v.IsNull() {
+		t.Errorf("expected null list, got %s", v)
+	}
+	if v := SetValue[string](&diags, nil); !v.IsNull() {
+		t.Errorf("expected null set, got %s", v)
+	}
+	if v := SetMapValue(&diags, map[string][]string{"ecommerce": {}}); !v.IsNull() {
+		t.Errorf("expected null map, got %s", v)
+	}
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestValues(t *testing.T) {
+	var diags diag.Diagnostics
+
+	labels := MapValue(&diags, map[string]string{"en_US": "Color"})
+	expectedLabels := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"en_US": types.StringValue("Color"),
+	})
+	if !labels.Equal(expectedLabels) {
+		t.Errorf("expected %s, got %s", expectedLabels, labels)
+	}
+
+	locales := SetValue(&diags, []string{"en_US", "de_DE", "en_US"})
+	if len(locales.Elements()) != 2 {
+		t.Errorf("expected duplicate elements to be dropped, got %s", locales)
+	}
+
+	orders := ListValue(&diags, []int64{2, 1})
+	expectedOrders := types.ListValueMust(types.Int64Type, []attr.Value{
+		types.Int64Value(2),
+		types.Int64Value(1),
+	})
+	if !orders.Equal(expectedOrders) {
+		t.Errorf("expected %s, got %s", expectedOrders, orders)
+	}
+
+	requirements := SetMapValue(&diags, map[string][]string{
+		"ecommerce": {"sku"},
+		"mobile":    {},
+	})
+	if len(requirements.Elements()) != 1 {
+		t.Errorf("expected empty sets to be left out, got %s", requirements)
+	}
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
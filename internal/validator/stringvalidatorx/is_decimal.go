@@ -0,0 +1,47 @@
+package stringvalidatorx
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"regexp"
+)
+
+var (
+	isDecimalRegexp = regexp.MustCompile(`^-?(\d+|\d*\.\d+)$`)
+)
+
+type isDecimal struct {
+}
+
+func (i isDecimal) Description(_ context.Context) string {
+	return "value must be a decimal number (example '0.001')"
+}
+
+func (i isDecimal) MarkdownDescription(ctx context.Context) string {
+	return i.Description(ctx)
+}
+
+func (i isDecimal) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	val := request.ConfigValue.ValueString()
+	if !IsValidDecimal(val) {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+			request.Path,
+			i.Description(ctx),
+			val,
+		))
+	}
+}
+
+// IsValidDecimal reports whether val is a decimal number as used by Akeneo conversion values.
+func IsValidDecimal(val string) bool {
+	return isDecimalRegexp.MatchString(val)
+}
+
+func IsDecimal() validator.String {
+	return isDecimal{}
+}
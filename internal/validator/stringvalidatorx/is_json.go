@@ -0,0 +1,38 @@
+package stringvalidatorx
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type isJSON struct {
+}
+
+func (i isJSON) Description(_ context.Context) string {
+	return "value must be a valid JSON document (use jsonencode)"
+}
+
+func (i isJSON) MarkdownDescription(ctx context.Context) string {
+	return i.Description(ctx)
+}
+
+func (i isJSON) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	val := request.ConfigValue.ValueString()
+	if !json.Valid([]byte(val)) {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			i.Description(ctx),
+			val,
+		))
+	}
+}
+
+func IsJSON() validator.String {
+	return isJSON{}
+}
@@ -28,7 +28,7 @@ func (i isLocaleCode) ValidateString(ctx context.Context, request validator.Stri
 	}
 
 	val := request.ConfigValue.ValueString()
-	if !isLocaleRegexp.MatchString(val) {
+	if !IsValidLocaleCode(val) {
 		response.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
 			request.Path,
 			i.Description(ctx),
@@ -37,6 +37,11 @@ func (i isLocaleCode) ValidateString(ctx context.Context, request validator.Stri
 	}
 }
 
+// IsValidLocaleCode reports whether val is a valid locale code.
+func IsValidLocaleCode(val string) bool {
+	return isLocaleRegexp.MatchString(val)
+}
+
 func IsLocaleCode() validator.String {
 	return isLocaleCode{}
 }